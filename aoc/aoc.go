@@ -0,0 +1,96 @@
+// Package aoc holds the registry of every day's solvers so a single binary can
+// run any of them.
+package aoc
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Solver computes the answer for one part of one day from the puzzle input.
+type Solver interface {
+	Solve(input string) (string, error)
+}
+
+// SolverFunc lets a plain function be registered as a Solver.
+type SolverFunc func(input string) (string, error)
+
+func (f SolverFunc) Solve(input string) (string, error) {
+	return f(input)
+}
+
+// Configurable is a Solver with options of its own. The runner only adds its
+// flags when that day and part is the one being run.
+type Configurable interface {
+	Solver
+	RegisterFlags(fs *flag.FlagSet)
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// wroteToStdout is set once any output has been sent to stdout.
+var wroteToStdout bool
+
+// CreateOutput opens where a solver writes anything it renders or exports.
+// An empty path or "-" means stdout.
+func CreateOutput(path string) (io.WriteCloser, error) {
+	if path == "" || path == "-" {
+		wroteToStdout = true
+		return nopCloser{os.Stdout}, nil
+	}
+	return os.Create(path)
+}
+
+// WroteToStdout is whether a solver has written a render or export to
+// stdout, so the runner knows to keep the answer out of it.
+func WroteToStdout() bool {
+	return wroteToStdout
+}
+
+type Key struct {
+	Day  int
+	Part int
+}
+
+var solvers = map[Key]Solver{}
+
+// Register is called from each day's init() to make a part available to the runner.
+func Register(day, part int, solver Solver) {
+	key := Key{Day: day, Part: part}
+	if _, ok := solvers[key]; ok {
+		panic(fmt.Sprintf("Day %d part %d registered twice", day, part))
+	}
+	solvers[key] = solver
+}
+
+func Lookup(day, part int) (Solver, error) {
+	solver, ok := solvers[Key{Day: day, Part: part}]
+	if !ok {
+		return nil, fmt.Errorf("No solver registered for day %d part %d", day, part)
+	}
+	return solver, nil
+}
+
+// Registered returns every registered day/part in order.
+func Registered() []Key {
+	var keys []Key
+	for k := range solvers {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Day != keys[j].Day {
+			return keys[i].Day < keys[j].Day
+		}
+		return keys[i].Part < keys[j].Part
+	})
+	return keys
+}
@@ -0,0 +1,65 @@
+package aoc
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+)
+
+// StreamSolver is a Solver that can read its input as it goes rather than
+// all at once, so the runner can hand it inputs larger than memory.
+type StreamSolver interface {
+	Solver
+	SolveStream(r io.Reader) (string, error)
+}
+
+// StreamFunc lets a plain function be registered as a StreamSolver.
+type StreamFunc func(r io.Reader) (string, error)
+
+func (f StreamFunc) Solve(input string) (string, error) {
+	return f(strings.NewReader(input))
+}
+
+func (f StreamFunc) SolveStream(r io.Reader) (string, error) {
+	return f(r)
+}
+
+// maxTokenSize is how long a single line can get. The input as a whole can be any size.
+const maxTokenSize = 64 * 1024 * 1024
+
+// Scan calls fn with each token split from r, along with its 1-based number.
+// It stops at the first error from either fn or reading r.
+func Scan(r io.Reader, split bufio.SplitFunc, fn func(token string, n int) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxTokenSize)
+	scanner.Split(split)
+
+	var n int
+	for scanner.Scan() {
+		n++
+		if err := fn(scanner.Text(), n); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// EachLine calls fn with each line of r, without the line ending.
+func EachLine(r io.Reader, fn func(line string, n int) error) error {
+	return Scan(r, bufio.ScanLines, fn)
+}
+
+// SplitOn makes a split function for inputs that are one long list, such as
+// comma separated steps, where a single line may not fit in memory.
+func SplitOn(sep byte) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.IndexByte(data, sep); i >= 0 {
+			return i + 1, data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+}
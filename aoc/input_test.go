@@ -0,0 +1,67 @@
+package aoc
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func collect(t *testing.T, input string, split bufio.SplitFunc) []string {
+	t.Helper()
+	var tokens []string
+	err := Scan(strings.NewReader(input), split, func(token string, n int) error {
+		if n != len(tokens)+1 {
+			t.Errorf("wanted token number %d, got %d", len(tokens)+1, n)
+		}
+		tokens = append(tokens, token)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tokens
+}
+
+func TestEachLine(t *testing.T) {
+	got := collect(t, "a b\r\n\nc\n", bufio.ScanLines)
+	if want := []string{"a b", "", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("wanted %q, got %q", want, got)
+	}
+
+	stop := errors.New("stop")
+	var seen int
+	err := EachLine(strings.NewReader("a\nb\nc"), func(line string, n int) error {
+		seen++
+		if line == "b" {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || seen != 2 {
+		t.Errorf("wanted to stop at line 2, got %v after %d lines", err, seen)
+	}
+}
+
+func TestSplitOn(t *testing.T) {
+	got := collect(t, "rn=1,cm-,,qp=3", SplitOn(','))
+	if want := []string{"rn=1", "cm-", "", "qp=3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("wanted %q, got %q", want, got)
+	}
+}
+
+func TestStreamFunc(t *testing.T) {
+	var solver Solver = StreamFunc(func(r io.Reader) (string, error) {
+		var count int
+		err := EachLine(r, func(string, int) error {
+			count++
+			return nil
+		})
+		return strings.Repeat("x", count), err
+	})
+	if got, _ := solver.Solve("a\nb\nc"); got != "xxx" {
+		t.Errorf("wanted %q, got %q", "xxx", got)
+	}
+}
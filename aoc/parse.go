@@ -0,0 +1,216 @@
+package aoc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseError points at the spot in the input where a parser gave up.
+type ParseError struct {
+	// File is left empty by the input parsers, since only the runner knows it.
+	File string
+	// Line and Column are 1-based. Column is 0 when the whole line is at fault.
+	Line     int
+	Column   int
+	Expected string
+	Got      string
+	// Source is the full text of the offending line, for the excerpt. Parsers
+	// that don't keep their lines around leave it and Column empty.
+	Source string
+	// SourceStart is the column Source starts at when it is only a piece of
+	// a line too long to show, otherwise 0.
+	SourceStart int
+	Err         error
+}
+
+func (e *ParseError) Error() string {
+	pos := strconv.Itoa(e.Line)
+	if e.Column > 0 {
+		pos += ":" + strconv.Itoa(e.Column)
+	}
+	if e.File != "" {
+		pos = e.File + ":" + pos
+	}
+
+	got := "nothing"
+	if e.Got != "" {
+		got = strconv.Quote(e.Got)
+	}
+	return fmt.Sprintf("%s: Expected %s, got %s", pos, e.Expected, got)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Excerpt shows the offending line with a caret under the column at fault.
+// It is empty when the parser only kept the line number.
+func (e *ParseError) Excerpt() string {
+	if e.Source == "" && e.Column == 0 {
+		return ""
+	}
+	number := strconv.Itoa(e.Line)
+	gutter := strings.Repeat(" ", len(number))
+
+	source := e.Source
+	column := e.Column
+	if e.SourceStart > 1 {
+		source = "..." + source
+		column = column - e.SourceStart + 1 + len("...")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s |\n", gutter)
+	fmt.Fprintf(&b, "%s | %s\n", number, source)
+	if column > 0 {
+		width := utf8.RuneCountInString(e.Got)
+		if width == 0 {
+			width = 1
+		}
+		// Keep tabs so the caret lines up however wide the terminal draws them.
+		var pad strings.Builder
+		for i, r := range []rune(source) {
+			if i >= column-1 {
+				break
+			}
+			if r == '\t' {
+				pad.WriteRune('\t')
+			} else {
+				pad.WriteRune(' ')
+			}
+		}
+		for i := len([]rune(source)); i < column-1; i++ {
+			pad.WriteRune(' ')
+		}
+		fmt.Fprintf(&b, "%s | %s%s\n", gutter, pad.String(), strings.Repeat("^", width))
+	}
+	return b.String()
+}
+
+// SourceLine is one line of the input, kept so errors can point back into it.
+type SourceLine struct {
+	Number int
+	Text   string
+	// Start is the column Text starts at when it is only a piece of a line
+	// too long to keep, otherwise 0.
+	Start int
+}
+
+// Field is a piece of a SourceLine along with its byte offset into the line.
+type Field struct {
+	Text   string
+	Offset int
+}
+
+// Whole is the entire line as a single field.
+func (l SourceLine) Whole() Field {
+	return Field{Text: l.Text, Offset: 0}
+}
+
+// End is the empty field just past the last character, for when something is missing.
+func (l SourceLine) End() Field {
+	return Field{Text: "", Offset: len(l.Text)}
+}
+
+// Errorf reports that the field is not what was expected.
+func (l SourceLine) Errorf(f Field, expected string, args ...any) *ParseError {
+	start := 1
+	if l.Start > 1 {
+		start = l.Start
+	}
+	return &ParseError{
+		Line:        l.Number,
+		Column:      start + utf8.RuneCountInString(l.Text[:f.Offset]),
+		Expected:    fmt.Sprintf(expected, args...),
+		Got:         f.Text,
+		Source:      l.Text,
+		SourceStart: l.Start,
+	}
+}
+
+// Int parses the field as a number in the given base.
+func (l SourceLine) Int(f Field, base, bitSize int) (int64, error) {
+	v, err := strconv.ParseInt(f.Text, base, bitSize)
+	if err != nil {
+		expected := "a number"
+		if base == 16 {
+			expected = "a hex number"
+		}
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			expected = fmt.Sprintf("%s that fits in %d bits", expected, bitSize)
+		}
+		perr := l.Errorf(f, expected)
+		perr.Err = err
+		return 0, perr
+	}
+	return v, nil
+}
+
+// Slice is the part of the field from start to end, like f.Text[start:end].
+func (f Field) Slice(start, end int) Field {
+	return Field{Text: f.Text[start:end], Offset: f.Offset + start}
+}
+
+// From is the rest of the field after start, like f.Text[start:].
+func (f Field) From(start int) Field {
+	return f.Slice(start, len(f.Text))
+}
+
+func (f Field) TrimSpace() Field {
+	start := len(f.Text) - len(strings.TrimLeftFunc(f.Text, unicode.IsSpace))
+	return Field{Text: strings.TrimSpace(f.Text), Offset: f.Offset + start}
+}
+
+// Cut splits the field around the first sep, like strings.Cut.
+func (f Field) Cut(sep string) (Field, Field, bool) {
+	i := strings.Index(f.Text, sep)
+	if i < 0 {
+		return f, Field{Text: "", Offset: f.Offset + len(f.Text)}, false
+	}
+	return f.Slice(0, i), f.From(i + len(sep)), true
+}
+
+// Split is strings.Split keeping track of where each piece came from.
+func (f Field) Split(sep string) []Field {
+	var fields []Field
+	for {
+		before, after, found := f.Cut(sep)
+		fields = append(fields, before)
+		if !found {
+			return fields
+		}
+		f = after
+	}
+}
+
+// Fields is strings.Fields keeping track of where each piece came from.
+func (f Field) Fields() []Field {
+	var fields []Field
+	start := -1
+	for i, r := range f.Text {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				fields = append(fields, f.Slice(start, i))
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, f.From(start))
+	}
+	return fields
+}
+
+// SourceLines splits contents into lines numbered from firstLine, dropping any \r.
+func SourceLines(contents string, firstLine int) []SourceLine {
+	var lines []SourceLine
+	for i, text := range strings.Split(contents, "\n") {
+		lines = append(lines, SourceLine{Number: firstLine + i, Text: strings.TrimSuffix(text, "\r")})
+	}
+	return lines
+}
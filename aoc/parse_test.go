@@ -0,0 +1,74 @@
+package aoc
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestFieldOffsets(t *testing.T) {
+	line := SourceLine{Number: 1, Text: "Card  1: 41 48 | 83  86"}
+	_, rest, _ := line.Whole().Cut(":")
+	winning, have, _ := rest.Cut("|")
+
+	tests := []struct {
+		got  Field
+		want Field
+	}{
+		{winning.Fields()[1], Field{Text: "48", Offset: 12}},
+		{have.Fields()[1], Field{Text: "86", Offset: 21}},
+		{rest.TrimSpace(), Field{Text: "41 48 | 83  86", Offset: 9}},
+		{line.Whole().Split(" ")[2], Field{Text: "1:", Offset: 6}},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("wanted %+v, got %+v", tt.want, tt.got)
+		}
+	}
+}
+
+func TestIntError(t *testing.T) {
+	line := SourceLine{Number: 3, Text: "seeds: 79 1x"}
+	_, err := line.Int(line.Whole().Fields()[2], 10, 64)
+
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("wanted a ParseError, got %v", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("wanted the strconv error to be wrapped, got %v", perr.Err)
+	}
+
+	perr.File = "input.txt"
+	if want := `input.txt:3:11: Expected a number, got "1x"`; perr.Error() != want {
+		t.Errorf("wanted %q, got %q", want, perr.Error())
+	}
+
+	want := "  |\n3 | seeds: 79 1x\n  |           ^^\n"
+	if perr.Excerpt() != want {
+		t.Errorf("wanted excerpt\n%s\ngot\n%s", want, perr.Excerpt())
+	}
+}
+
+func TestPieceOfLongLine(t *testing.T) {
+	step := SourceLine{Number: 1, Text: "qp=x", Start: 40}
+	perr := step.Errorf(step.Whole().From(3), "a focal length")
+	if perr.Column != 43 {
+		t.Errorf("wanted column 43, got %d", perr.Column)
+	}
+
+	want := "  |\n1 | ...qp=x\n  |       ^\n"
+	if perr.Excerpt() != want {
+		t.Errorf("wanted excerpt\n%s\ngot\n%s", want, perr.Excerpt())
+	}
+}
+
+func TestLineOnly(t *testing.T) {
+	perr := &ParseError{Line: 7, Expected: "a trench that doesn't cross", Got: "R 6"}
+	if want := `7: Expected a trench that doesn't cross, got "R 6"`; perr.Error() != want {
+		t.Errorf("wanted %q, got %q", want, perr.Error())
+	}
+	if perr.Excerpt() != "" {
+		t.Errorf("wanted no excerpt, got\n%s", perr.Excerpt())
+	}
+}
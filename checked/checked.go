@@ -0,0 +1,53 @@
+// Package checked is int64 arithmetic that reports an overflow instead of
+// silently wrapping around to a wrong answer.
+package checked
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var ErrOverflow = errors.New("int64 overflow")
+
+// OverflowError says which operation overflowed. It matches ErrOverflow with errors.Is.
+type OverflowError struct {
+	Op string
+	A  int64
+	B  int64
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("Overflow computing %d %s %d in int64", e.A, e.Op, e.B)
+}
+
+func (e *OverflowError) Is(target error) bool {
+	return target == ErrOverflow
+}
+
+func Add(a, b int64) (int64, error) {
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return 0, &OverflowError{Op: "+", A: a, B: b}
+	}
+	return c, nil
+}
+
+func Sub(a, b int64) (int64, error) {
+	c := a - b
+	if (b > 0 && c > a) || (b < 0 && c < a) {
+		return 0, &OverflowError{Op: "-", A: a, B: b}
+	}
+	return c, nil
+}
+
+func Mul(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, &OverflowError{Op: "*", A: a, B: b}
+	}
+	return c, nil
+}
@@ -0,0 +1,39 @@
+package checked
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestOverflow(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(a, b int64) (int64, error)
+		a, b int64
+		want int64
+		ok   bool
+	}{
+		{"add", Add, 2, 3, 5, true},
+		{"add max", Add, math.MaxInt64, 1, 0, false},
+		{"add min", Add, math.MinInt64, -1, 0, false},
+		{"sub", Sub, 2, 3, -1, true},
+		{"sub min", Sub, math.MinInt64, 1, 0, false},
+		{"sub max", Sub, math.MaxInt64, -1, 0, false},
+		{"mul", Mul, -4, 3, -12, true},
+		{"mul zero", Mul, 0, math.MinInt64, 0, true},
+		{"mul big", Mul, 1 << 32, 1 << 31, 0, false},
+		{"mul min by -1", Mul, math.MinInt64, -1, 0, false},
+		{"mul -1 by min", Mul, -1, math.MinInt64, 0, false},
+	}
+	for _, tt := range tests {
+		got, err := tt.fn(tt.a, tt.b)
+		if tt.ok {
+			if err != nil || got != tt.want {
+				t.Errorf("%s: wanted %d, got %d, %v", tt.name, tt.want, got, err)
+			}
+		} else if !errors.Is(err, ErrOverflow) {
+			t.Errorf("%s: wanted an overflow, got %d, %v", tt.name, got, err)
+		}
+	}
+}
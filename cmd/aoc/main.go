@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+	_ "github.com/HallM/aoc2023/days"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage:\n")
+	fmt.Fprintf(os.Stderr, "  aoc run --day N --part M --input FILE [solver flags]\n")
+	fmt.Fprintf(os.Stderr, "  aoc list\n")
+}
+
+// scanIntFlag finds the value of an int flag before the full flag set is
+// known, since which solver is selected decides which other flags exist.
+func scanIntFlag(args []string, name string, fallback int) int {
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		key, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if key != name {
+			continue
+		}
+		if !hasValue && i+1 < len(args) {
+			value = args[i+1]
+		}
+		if v, err := strconv.Atoi(value); err == nil {
+			return v
+		}
+	}
+	return fallback
+}
+
+func runCommand(args []string) {
+	solver, err := aoc.Lookup(scanIntFlag(args, "day", 0), scanIntFlag(args, "part", 1))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	fs.Int("day", 0, "Day to run")
+	fs.Int("part", 1, "Part of the day to run")
+	inputPath := fs.String("input", "", "File path to the puzzle input")
+	if c, ok := solver.(aoc.Configurable); ok {
+		c.RegisterFlags(fs)
+	}
+	fs.Parse(args)
+
+	if *inputPath == "" {
+		log.Fatalf("Must specify the input file!")
+	}
+
+	answer, err := solve(solver, *inputPath)
+	var perr *aoc.ParseError
+	if errors.As(err, &perr) {
+		if perr.File == "" {
+			perr.File = *inputPath
+		}
+		log.Print(err)
+		fmt.Fprint(os.Stderr, perr.Excerpt())
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Anything rendered or exported to stdout would be corrupted by the answer after it.
+	if aoc.WroteToStdout() {
+		fmt.Fprintln(os.Stderr, answer)
+		return
+	}
+	fmt.Println(answer)
+}
+
+// solve streams the input to solvers that can take it, and otherwise reads it all up front.
+func solve(solver aoc.Solver, inputPath string) (string, error) {
+	if s, ok := solver.(aoc.StreamSolver); ok {
+		f, err := os.Open(inputPath)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		return s.SolveStream(f)
+	}
+
+	contents, err := os.ReadFile(inputPath)
+	if err != nil {
+		return "", err
+	}
+	return solver.Solve(string(contents))
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "run":
+		runCommand(os.Args[2:])
+	case "list":
+		for _, k := range aoc.Registered() {
+			fmt.Printf("day %d part %d\n", k.Day, k.Part)
+		}
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
@@ -1,13 +1,12 @@
-package main
+package day1
 
 import (
-	"flag"
+	"io"
 	"log"
-	"os"
-	"strings"
-)
+	"strconv"
 
-var docFilePath = flag.String("doc", "part1test.txt", "File path to the calibration doc")
+	"github.com/HallM/aoc2023/aoc"
+)
 
 var runeDigitMap = map[rune]int {
 	'0': 0,
@@ -22,14 +21,13 @@ var runeDigitMap = map[rune]int {
 	'9': 9,
 }
 
-func computeCalibration(doc string) int {
-	lines := strings.Split(doc, "\n")
-
+func computeCalibration(doc io.Reader) (int, error) {
 	var total int
-	for _, line := range lines {
+	err := aoc.EachLine(doc, func(line string, n int) error {
 		total += computeCalibrationLine(line)
-	}
-	return total
+		return nil
+	})
+	return total, err
 }
 
 func computeCalibrationLine(line string) int {
@@ -48,17 +46,15 @@ func computeCalibrationLine(line string) int {
 	return (10 * first) + last
 }
 
-func main() {
-	flag.Parse()
-
-	if *docFilePath == "" {
-		log.Fatalf("Must specify the calibration doc!")
-	}
+func init() {
+	aoc.Register(1, 1, aoc.StreamFunc(solvePart1))
+}
 
-	doc, err := os.ReadFile(*docFilePath)
+func solvePart1(doc io.Reader) (string, error) {
+	calibration, err := computeCalibration(doc)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
 	}
-	calibration := computeCalibration(string(doc))
 	log.Printf("Calibration: %d", calibration)
+	return strconv.Itoa(calibration), nil
 }
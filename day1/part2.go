@@ -1,14 +1,14 @@
-package main
+package day1
 
 import (
-	"flag"
+	"io"
 	"log"
-	"os"
 	"regexp"
+	"strconv"
 	"strings"
-)
 
-var docFilePath = flag.String("doc", "part2test.txt", "File path to the calibration doc")
+	"github.com/HallM/aoc2023/aoc"
+)
 
 var matchDigitMap = map[string]int {
 	"0": 0,
@@ -34,17 +34,16 @@ var matchDigitMap = map[string]int {
 	"nine": 9,
 }
 
-func computeCalibration(doc string) int {
-	lines := strings.Split(doc, "\n")
-
+func computeSpelledCalibration(doc io.Reader) (int, error) {
 	var total int
-	for _, line := range lines {
-		total += computeCalibrationLine(strings.TrimSpace(line))
-	}
-	return total
+	err := aoc.EachLine(doc, func(line string, n int) error {
+		total += computeSpelledCalibrationLine(strings.TrimSpace(line))
+		return nil
+	})
+	return total, err
 }
 
-func computeCalibrationLine(line string) int {
+func computeSpelledCalibrationLine(line string) int {
 	var first, last int
 	var hasFirst bool
 
@@ -69,17 +68,15 @@ func computeCalibrationLine(line string) int {
 	return (10 * first) + last
 }
 
-func main() {
-	flag.Parse()
-
-	if *docFilePath == "" {
-		log.Fatalf("Must specify the calibration doc!")
-	}
+func init() {
+	aoc.Register(1, 2, aoc.StreamFunc(solvePart2))
+}
 
-	doc, err := os.ReadFile(*docFilePath)
+func solvePart2(doc io.Reader) (string, error) {
+	calibration, err := computeSpelledCalibration(doc)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
 	}
-	calibration := computeCalibration(string(doc))
 	log.Printf("Calibration: %d", calibration)
+	return strconv.Itoa(calibration), nil
 }
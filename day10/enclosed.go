@@ -0,0 +1,48 @@
+package day10
+
+import (
+	"github.com/HallM/aoc2023/grid"
+)
+
+// enclosedByPick counts the tiles inside the loop from its area. The shoelace
+// formula gives the area of the polygon through the middle of each loop tile,
+// and Pick's theorem (area = interior + boundary/2 - 1) leaves the tiles inside.
+func enclosedByPick(loop []grid.Point) int {
+	var twiceArea int
+	for i, a := range loop {
+		b := loop[(i+1)%len(loop)]
+		twiceArea += a.X*b.Y - a.Y*b.X
+	}
+	if twiceArea < 0 {
+		twiceArea = -twiceArea
+	}
+	return (twiceArea-len(loop))/2 + 1
+}
+
+// enclosedByScanline goes along each row counting how many times it has
+// crossed the loop, so a tile is inside after an odd number. Only loop tiles
+// that open to the north count as a crossing, so running along the top of
+// a bend like F-J counts once but F-7 doesn't count at all.
+func (pipeMap *PipeMap) enclosedByScanline(loop []grid.Point) int {
+	onLoop := grid.New[bool](pipeMap.Width, pipeMap.Height)
+	for _, p := range loop {
+		onLoop.Set(p.X, p.Y, true)
+	}
+
+	var count int
+	for y := 0; y < pipeMap.Height; y++ {
+		inside := false
+		for x := 0; x < pipeMap.Width; x++ {
+			if !onLoop.Get(x, y) {
+				if inside {
+					count++
+				}
+				continue
+			}
+			if opensTowards(pipeMap.Get(x, y), grid.North) {
+				inside = !inside
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,51 @@
+package day10
+
+import (
+	"os"
+	"testing"
+)
+
+// bends has a row along the bottom of a notch in the top of the loop, L-J,
+// and the top of a bump in the bottom of it, F-7. Only the first is a crossing.
+const bends = `F7.F----7
+||.|....|
+|L-JF-7.S
+|...|.|.|
+L---J.L-J
+`
+
+func readSample(t *testing.T, name string) string {
+	t.Helper()
+	contents, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(contents)
+}
+
+func TestEnclosedMethods(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     string
+	}{
+		{"part1test.txt", readSample(t, "part1test.txt"), "1"},
+		{"part2test.txt", readSample(t, "part2test.txt"), "10"},
+		{"bends", bends, "9"},
+	}
+	for _, tt := range tests {
+		for _, s := range []*enclosedSolver{
+			{method: "pick"},
+			{method: "scanline"},
+			{method: "pick", crossCheck: true},
+			{method: "scanline", crossCheck: true},
+		} {
+			got, err := s.Solve(tt.contents)
+			if err != nil {
+				t.Errorf("%s with %s (cross check %v): %v", tt.name, s.method, s.crossCheck, err)
+			} else if got != tt.want {
+				t.Errorf("%s with %s (cross check %v): wanted %s, got %s", tt.name, s.method, s.crossCheck, tt.want, got)
+			}
+		}
+	}
+}
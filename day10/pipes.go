@@ -0,0 +1,312 @@
+package day10
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+	"github.com/HallM/aoc2023/grid"
+	"github.com/HallM/aoc2023/search"
+)
+
+const (
+	PIPE_GROUND = iota
+	PIPE_START
+	PIPE_NS
+	PIPE_EW
+	PIPE_NE
+	PIPE_NW
+	PIPE_SW
+	PIPE_SE
+)
+
+const (
+	FROM_NORTH = iota
+	FROM_EAST
+	FROM_SOUTH
+	FROM_WEST
+)
+
+var charToPipe = map[rune]int {
+	'.': PIPE_GROUND,
+	'S': PIPE_START,
+	'|': PIPE_NS,
+	'-': PIPE_EW,
+	'L': PIPE_NE,
+	'J': PIPE_NW,
+	'7': PIPE_SW,
+	'F': PIPE_SE,
+}
+var pipeToChar = map[int]rune {
+	PIPE_GROUND: ' ',
+	PIPE_START: 'S',
+	PIPE_NS: '|',
+	PIPE_EW: '-',
+	PIPE_NE: 'L',
+	PIPE_NW: 'J',
+	PIPE_SW: '7',
+	PIPE_SE: 'F',
+}
+
+// the sides of each pipe which can connect to a neighbor. The start is
+// swapped for the pipe under it as soon as the map is parsed.
+var pipeOpenings = map[int][]grid.Point {
+	PIPE_NS: []grid.Point{ grid.North, grid.South },
+	PIPE_EW: []grid.Point{ grid.East, grid.West },
+	PIPE_NE: []grid.Point{ grid.North, grid.East },
+	PIPE_NW: []grid.Point{ grid.North, grid.West },
+	PIPE_SW: []grid.Point{ grid.South, grid.West },
+	PIPE_SE: []grid.Point{ grid.South, grid.East },
+}
+
+// startPipes are the pipes that could be under the start, in the order they're tried.
+var startPipes = []int{PIPE_NS, PIPE_EW, PIPE_NE, PIPE_NW, PIPE_SW, PIPE_SE}
+
+// arrivedFromMoving is the side a seeker comes in from after moving in a direction.
+var arrivedFromMoving = map[grid.Point]int {
+	grid.North: FROM_SOUTH,
+	grid.East: FROM_WEST,
+	grid.South: FROM_NORTH,
+	grid.West: FROM_EAST,
+}
+
+type Seeker struct {
+	id int
+	x int
+	y int
+	arrivedFrom int
+	distance int
+	indices []int
+}
+
+type PipeMap struct {
+	*grid.Grid[int]
+	startX int
+	startY int
+}
+
+func (m *PipeMap) print() {
+	for y, row := range m.Lines(func(c int) rune { return pipeToChar[c] }) {
+		log.Printf("%d: %s", y, row)
+	}
+}
+
+func opensTowards(pipe int, side grid.Point) bool {
+	for _, o := range pipeOpenings[pipe] {
+		if o == side {
+			return true
+		}
+	}
+	return false
+}
+
+// connectedPipes are the neighbors of p which connect back to p, each 1 step away.
+func (m *PipeMap) connectedPipes(p grid.Point) []search.Edge[grid.Point] {
+	var edges []search.Edge[grid.Point]
+	for _, o := range pipeOpenings[m.Get(p.X, p.Y)] {
+		n := p.Add(o)
+		if !m.InBounds(n.X, n.Y) {
+			continue
+		}
+		if opensTowards(m.Get(n.X, n.Y), grid.Point{X: -o.X, Y: -o.Y}) {
+			edges = append(edges, search.Edge[grid.Point]{To: n, Cost: 1})
+		}
+	}
+	return edges
+}
+
+func (m *PipeMap) canMakeSeeker(x, y int) bool {
+	if !m.InBounds(x, y) {
+		return false
+	}
+	if m.Get(x, y) == PIPE_GROUND {
+		return false
+	}
+	return true
+}
+
+type indexForFn func(x, y int) int
+func (s *Seeker) makeNorth(indexer indexForFn) *Seeker {
+	return &Seeker{s.id, s.x, s.y-1, FROM_SOUTH, s.distance+1, append(s.indices, indexer(s.x, s.y-1))}
+}
+func (s *Seeker) makeEast(indexer indexForFn) *Seeker {
+	return &Seeker{s.id, s.x+1, s.y, FROM_WEST, s.distance+1, append(s.indices, indexer(s.x+1, s.y))}
+}
+func (s *Seeker) makeSouth(indexer indexForFn) *Seeker {
+	return &Seeker{s.id, s.x, s.y+1, FROM_NORTH, s.distance+1, append(s.indices, indexer(s.x, s.y+1))}
+}
+func (s *Seeker) makeWest(indexer indexForFn) *Seeker {
+	return &Seeker{s.id, s.x-1, s.y, FROM_EAST, s.distance+1, append(s.indices, indexer(s.x-1, s.y))}
+}
+
+func (pipeMap *PipeMap) findLoopingSeekers() []*Seeker {
+	startIndex := pipeMap.Index(pipeMap.startX, pipeMap.startY)
+
+	// only send seekers out the way the pipe under the start opens
+	var seekers []*Seeker
+	start := grid.Point{X: pipeMap.startX, Y: pipeMap.startY}
+	for id, o := range pipeOpenings[pipeMap.Get(start.X, start.Y)] {
+		n := start.Add(o)
+		if pipeMap.canMakeSeeker(n.X, n.Y) {
+			index := pipeMap.Index(n.X, n.Y)
+			seekers = append(seekers, &Seeker{id+1, n.X, n.Y, arrivedFromMoving[o], 1, []int{startIndex, index}})
+		}
+	}
+
+	for len(seekers) > 0 {
+		var next []*Seeker
+
+		for _, s := range seekers {
+			t := pipeMap.Get(s.x, s.y)
+
+			var newSeeker *Seeker
+			if t == PIPE_NS {
+				if s.arrivedFrom == FROM_NORTH {
+					newSeeker = s.makeSouth(pipeMap.Index)
+				} else if s.arrivedFrom == FROM_SOUTH {
+					newSeeker = s.makeNorth(pipeMap.Index)
+				}
+			} else if t == PIPE_EW {
+				if s.arrivedFrom == FROM_EAST {
+					newSeeker = s.makeWest(pipeMap.Index)
+				} else if s.arrivedFrom == FROM_WEST {
+					newSeeker = s.makeEast(pipeMap.Index)
+				}
+			} else if t == PIPE_NE {
+				if s.arrivedFrom == FROM_NORTH {
+					newSeeker = s.makeEast(pipeMap.Index)
+				} else if s.arrivedFrom == FROM_EAST {
+					newSeeker = s.makeNorth(pipeMap.Index)
+				}
+			} else if t == PIPE_NW {
+				if s.arrivedFrom == FROM_NORTH {
+					newSeeker = s.makeWest(pipeMap.Index)
+				} else if s.arrivedFrom == FROM_WEST {
+					newSeeker = s.makeNorth(pipeMap.Index)
+				}
+			} else if t == PIPE_SW {
+				if s.arrivedFrom == FROM_SOUTH {
+					newSeeker = s.makeWest(pipeMap.Index)
+				} else if s.arrivedFrom == FROM_WEST {
+					newSeeker = s.makeSouth(pipeMap.Index)
+				}
+			} else if t == PIPE_SE {
+				if s.arrivedFrom == FROM_SOUTH {
+					newSeeker = s.makeEast(pipeMap.Index)
+				} else if s.arrivedFrom == FROM_EAST {
+					newSeeker = s.makeSouth(pipeMap.Index)
+				}
+			}
+			if newSeeker == nil {
+				// probably hit a wall / invalid pipe
+				continue
+			}
+
+			if !pipeMap.canMakeSeeker(newSeeker.x, newSeeker.y) {
+				continue
+			}
+
+			for _, s2 := range next {
+				if newSeeker.x == s2.x && newSeeker.y == s2.y {
+					return []*Seeker{newSeeker, s2}
+				}
+			}
+			next = append(next, newSeeker)
+		}
+		seekers = next
+	}
+	return nil
+}
+
+// loopTiles is the loop through the start, in order. The two seekers that
+// met went round it in opposite directions, so one is walked forwards and
+// the other backwards.
+func (pipeMap *PipeMap) loopTiles() ([]grid.Point, error) {
+	seekers := pipeMap.findLoopingSeekers()
+	if seekers == nil {
+		return nil, errors.New("No loop goes through the start")
+	}
+
+	var loop []grid.Point
+	point := func(index int) grid.Point {
+		return grid.Point{X: index % pipeMap.Width, Y: index / pipeMap.Width}
+	}
+	for _, i := range seekers[0].indices {
+		loop = append(loop, point(i))
+	}
+	// skip where they met, which is already in, and the start
+	back := seekers[1].indices
+	for i := len(back) - 2; i > 0; i-- {
+		loop = append(loop, point(back[i]))
+	}
+	return loop, nil
+}
+
+func parseMap(contents string) (*PipeMap, error) {
+	g, err := grid.ParseMap(contents, charToPipe)
+	if err != nil {
+		return nil, err
+	}
+
+	// find S in the text too, so errors can point at it
+	var starts []aoc.Field
+	var startLines []aoc.SourceLine
+	for _, line := range aoc.SourceLines(contents, 1) {
+		for i, r := range line.Text {
+			if r == 'S' {
+				starts = append(starts, line.Whole().Slice(i, i+1))
+				startLines = append(startLines, line)
+			}
+		}
+	}
+	if len(starts) == 0 {
+		return nil, errors.New("No start tile S in the map")
+	}
+	if len(starts) > 1 {
+		return nil, startLines[1].Errorf(starts[1], "only one start tile, the first is on line %d", startLines[0].Number)
+	}
+
+	var startX, startY int
+	for i, c := range g.Cells() {
+		if c == PIPE_START {
+			startX = i % g.Width
+			startY = i / g.Width
+		}
+	}
+	pipeMap := &PipeMap{g, startX, startY}
+
+	fits := pipeMap.startFits()
+	if len(fits) != 1 {
+		var names []string
+		for _, p := range fits {
+			names = append(names, string(pipeToChar[p]))
+		}
+		found := "none do"
+		if len(fits) > 1 {
+			found = strings.Join(names, ", ") + " all do"
+		}
+		return nil, startLines[0].Errorf(starts[0], "exactly one of | - L J 7 F to connect S to its neighbors, but %s", found)
+	}
+	log.Printf("The start is a %c", pipeToChar[fits[0]])
+	pipeMap.Set(startX, startY, fits[0])
+	return pipeMap, nil
+}
+
+// startFits are the pipes which could be under the start, being the ones
+// where both of the neighbors it opens towards open back towards it.
+func (m *PipeMap) startFits() []int {
+	start := grid.Point{X: m.startX, Y: m.startY}
+	var fits []int
+	for _, pipe := range startPipes {
+		fit := true
+		for _, o := range pipeOpenings[pipe] {
+			n := start.Add(o)
+			fit = fit && m.InBounds(n.X, n.Y) && opensTowards(m.Get(n.X, n.Y), grid.Point{X: -o.X, Y: -o.Y})
+		}
+		if fit {
+			fits = append(fits, pipe)
+		}
+	}
+	return fits
+}
@@ -0,0 +1,92 @@
+package day10
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/HallM/aoc2023/grid"
+)
+
+var sideNames = map[grid.Point]string{
+	grid.North: "north",
+	grid.East:  "east",
+	grid.South: "south",
+	grid.West:  "west",
+}
+
+func (m *PipeMap) describe(p grid.Point) string {
+	return fmt.Sprintf("%c at (%d, %d)", pipeToChar[m.Get(p.X, p.Y)], p.X, p.Y)
+}
+
+// problems lists every pipe that leads nowhere or into a pipe that doesn't
+// lead back, and every loop when there is more than one.
+func (m *PipeMap) problems() []string {
+	var problems []string
+	for i, pipe := range m.Cells() {
+		p := grid.Point{X: i % m.Width, Y: i / m.Width}
+		for _, o := range pipeOpenings[pipe] {
+			n := p.Add(o)
+			switch {
+			case !m.InBounds(n.X, n.Y):
+				problems = append(problems, fmt.Sprintf("%s dangles off the map to the %s", m.describe(p), sideNames[o]))
+			case m.Get(n.X, n.Y) == PIPE_GROUND:
+				problems = append(problems, fmt.Sprintf("%s dangles into the ground to the %s", m.describe(p), sideNames[o]))
+			case !opensTowards(m.Get(n.X, n.Y), grid.Point{X: -o.X, Y: -o.Y}):
+				problems = append(problems, fmt.Sprintf("%s connects %s to %s, which doesn't connect back", m.describe(p), sideNames[o], m.describe(n)))
+			}
+		}
+	}
+
+	loops := m.loops()
+	if len(loops) > 1 {
+		for _, first := range loops {
+			problems = append(problems, fmt.Sprintf("%d separate loops, one goes through %s", len(loops), m.describe(first)))
+		}
+	}
+	return problems
+}
+
+// loops finds the first tile of every group of pipes that all connect to
+// each other both ways. Every pipe has two openings, so that's a loop.
+func (m *PipeMap) loops() []grid.Point {
+	seen := grid.New[bool](m.Width, m.Height)
+	var loops []grid.Point
+	for i, pipe := range m.Cells() {
+		if pipe == PIPE_GROUND || seen.Cells()[i] {
+			continue
+		}
+		first := grid.Point{X: i % m.Width, Y: i / m.Width}
+		closed := true
+		queue := []grid.Point{first}
+		seen.Set(first.X, first.Y, true)
+		for len(queue) > 0 {
+			p := queue[0]
+			queue = queue[1:]
+			edges := m.connectedPipes(p)
+			closed = closed && len(edges) == 2
+			for _, e := range edges {
+				if !seen.Get(e.To.X, e.To.Y) {
+					seen.Set(e.To.X, e.To.Y, true)
+					queue = append(queue, e.To)
+				}
+			}
+		}
+		if closed {
+			loops = append(loops, first)
+		}
+	}
+	return loops
+}
+
+// checkPipes logs every problem with the map and fails if there are any.
+func checkPipes(m *PipeMap) error {
+	problems := m.problems()
+	for _, p := range problems {
+		log.Print(p)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("Found %d problems with the pipe map", len(problems))
+	}
+	log.Printf("The pipe map is one clean loop")
+	return nil
+}
@@ -0,0 +1,76 @@
+package day10
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+func TestStartErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		contents     string
+		line, column int
+		expected     string
+	}{
+		{"nothing fits", "S.\n..\n", 1, 1,
+			"exactly one of | - L J 7 F to connect S to its neighbors, but none do"},
+		{"everything fits", ".|.\n-S-\n.|.\n", 2, 2,
+			"exactly one of | - L J 7 F to connect S to its neighbors, but |, -, L, J, 7, F all do"},
+		{"two starts", "S7S7\nLJLJ\n", 1, 3,
+			"only one start tile, the first is on line 1"},
+	}
+	for _, tt := range tests {
+		_, err := parseMap(tt.contents)
+		var perr *aoc.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("%s: wanted a ParseError, got %v", tt.name, err)
+			continue
+		}
+		if perr.Line != tt.line || perr.Column != tt.column || perr.Expected != tt.expected {
+			t.Errorf("%s: wanted %d:%d expecting %q, got %v", tt.name, tt.line, tt.column, tt.expected, perr)
+		}
+	}
+
+	if _, err := parseMap("F7\nLJ\n"); err == nil || err.Error() != "No start tile S in the map" {
+		t.Errorf("no start: wanted an error, got %v", err)
+	}
+}
+
+func TestProblems(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     []string
+	}{
+		{"clean loop", "S7\nLJ\n", nil},
+		{"two loops", "S7.F7\nLJ.LJ\n", []string{
+			"2 separate loops, one goes through F at (0, 0)",
+			"2 separate loops, one goes through F at (3, 0)",
+		}},
+		{"dangling pipes", "S7..|\nLJ-.7\n", []string{
+			"| at (4, 0) dangles off the map to the north",
+			"| at (4, 0) connects south to 7 at (4, 1), which doesn't connect back",
+			"- at (2, 1) dangles into the ground to the east",
+			"- at (2, 1) connects west to J at (1, 1), which doesn't connect back",
+			"7 at (4, 1) dangles off the map to the south",
+			"7 at (4, 1) dangles into the ground to the west",
+		}},
+	}
+	for _, tt := range tests {
+		m, err := parseMap(tt.contents)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := m.problems(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: wanted problems\n%q\ngot\n%q", tt.name, tt.want, got)
+		}
+		err = checkPipes(m)
+		if (err == nil) != (len(tt.want) == 0) {
+			t.Errorf("%s: wanted checkPipes to fail only if there are problems, got %v", tt.name, err)
+		}
+	}
+}
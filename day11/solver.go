@@ -0,0 +1,47 @@
+package day11
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+// Note that there are a small number of rows/cols, so sticking with int is fine.
+const expansionRate = 1000000 - 1
+
+type universeSolver struct {
+	expansionRate int
+	bigint bool
+}
+
+func init() {
+	aoc.Register(11, 1, &universeSolver{expansionRate: 1})
+	aoc.Register(11, 2, &universeSolver{expansionRate: expansionRate})
+}
+
+func (s *universeSolver) RegisterFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&s.bigint, "bigint", false, "Use arbitrary precision instead of failing when the answer overflows an int64")
+}
+
+func (s *universeSolver) Solve(contents string) (string, error) {
+	universe, err := parseMap(contents, s.expansionRate)
+	if err != nil {
+		return "", err
+	}
+
+	if s.bigint {
+		total := universe.sumDistancesBig()
+		log.Printf("Sum: %s", total)
+		return total.String(), nil
+	}
+
+	total, err := universe.sumDistances()
+	if err != nil {
+		return "", fmt.Errorf("%w, try --bigint", err)
+	}
+	log.Printf("Sum: %d", total)
+	return strconv.FormatInt(total, 10), nil
+}
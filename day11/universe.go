@@ -0,0 +1,116 @@
+package day11
+
+import (
+	"log"
+	"math/big"
+
+	"github.com/HallM/aoc2023/aoc"
+	"github.com/HallM/aoc2023/checked"
+)
+
+type Galaxy struct {
+	id int
+	x int
+	y int
+}
+
+type Universe struct {
+	galaxies []*Galaxy
+}
+
+// Each empty row or column is replaced by expansionRate+1 rows or columns.
+func parseMap(contents string, expansionRate int) (*Universe, error) {
+	var galaxies []*Galaxy
+
+	nextId := 1
+	yExpand := 0
+
+	columnCounts := map[int]int{}
+
+	lines := aoc.SourceLines(contents, 1)
+	if len(lines) == 1 {
+		return &Universe{}, nil
+	}
+
+	width := len(lines[0].Text)
+
+	for y, source := range lines {
+		field := source.Whole().TrimSpace()
+		line := field.Text
+
+		hadOne := false
+		for x, c := range line {
+			if c != '#' && c != '.' {
+				return nil, source.Errorf(field.Slice(x, x+1), "%q or %q", '#', '.')
+			}
+			if c == '#' {
+				hadOne = true
+				columnCounts[x] = columnCounts[x] + 1
+				galaxies = append(galaxies, &Galaxy{id: nextId, x: x, y: y + yExpand})
+				nextId++
+			}
+		}
+		if !hadOne {
+			yExpand += expansionRate
+		}
+	}
+
+	xMapping := map[int]int{}
+	xExpand := 0
+	for x := 0; x < width; x++ {
+		xMapping[x] = x + xExpand
+		if columnCounts[x] == 0 {
+			xExpand += expansionRate
+		}
+	}
+
+	log.Printf("%d rows, %d cols", yExpand, xExpand)
+
+	for _, g := range galaxies {
+		g.x = xMapping[g.x]
+	}
+
+	return &Universe{galaxies: galaxies}, nil
+}
+
+func (u *Universe) eachDistance(fn func(path int64) error) error {
+	for i, a := range u.galaxies {
+		for j, b := range u.galaxies {
+			if i == j {
+				break
+			}
+			path := abs(int64(a.x) - int64(b.x)) + abs(int64(a.y) - int64(b.y))
+			// log.Printf("%d -> %d is %d units", b.id, a.id, path)
+			if err := fn(path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (u *Universe) sumDistances() (int64, error) {
+	total := int64(0)
+	err := u.eachDistance(func(path int64) error {
+		var err error
+		total, err = checked.Add(total, path)
+		return err
+	})
+	return total, err
+}
+
+func (u *Universe) sumDistancesBig() *big.Int {
+	total := new(big.Int)
+	u.eachDistance(func(path int64) error {
+		total.Add(total, big.NewInt(path))
+		return nil
+	})
+	return total
+}
+
+func abs(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -1,114 +1,21 @@
-package main
+package day12
 
 import (
-	"flag"
-	"fmt"
-	"log"
-	"os"
-	"strconv"
-	"strings"
-)
+	"math/big"
 
-var filePath = flag.String("file", "part1test.txt", "File path")
+	"github.com/HallM/aoc2023/aoc"
+	"github.com/HallM/aoc2023/checked"
+)
 
 const MULTIPLIER = 5
 
-const (
-	PART_UNKNOWN = iota
-	PART_OPERATIONAL
-	PART_BROKEN
-)
-
 var cache = map[string]int64{}
+var bigCache = map[string]*big.Int{}
 
-type Row struct {
-	line string
-	parts []int
-	checksum []int
-}
-
-func makeRow(parts []int, checksum []int) *Row {
-	var partString []rune
-	for _, p := range parts {
-		if p == PART_UNKNOWN {
-			partString = append(partString, '?')
-		} else if p == PART_OPERATIONAL {
-			partString = append(partString, '.')
-		} else if p == PART_BROKEN {
-			partString = append(partString, '#')
-		}
-	}
-	partString = append(partString, ' ')
-
-	var cs []string
-	for _, c := range checksum {
-		cs = append(cs, fmt.Sprintf("%d", c))
-	}
-
-	line := string(partString) + strings.Join(cs, ",")
-	return &Row{line: line, parts: parts, checksum: checksum}
-}
-
-func (r *Row) isPossible() bool {
-	if len(r.parts) == 0 && len(r.checksum) == 0 {
-		return true
-	}
-
-	req := r.minRequired()
-	if (req + len(r.checksum) - 1) > len(r.parts) {
-		return false
-	}
-	numBroke := r.numMaybeDamaged()
-	if numBroke < req {
-		return false
-	}
-	return true
-}
-
-func (r *Row) minRequired() int {
-	if len(r.checksum) == 0 {
-		return 0
-	}
-
-	var val int
-	for _, c := range r.checksum {
-		val += c
-	}
-	return val
-}
-
-func (r *Row) numMaybeDamaged() int {
-	var op int
-	for _, p := range r.parts {
-		if p == PART_BROKEN || p == PART_UNKNOWN {
-			op++
-		}
-	}
-	return op
-}
-
-func parseRow(line string) *Row {
-	line = strings.TrimSpace(line)
-
-	var parts []int
-	var checksumStart int
-	for i, r := range line {
-		if r == '.' {
-			parts = append(parts, PART_OPERATIONAL)
-		} else if r == '#' {
-			parts = append(parts, PART_BROKEN)
-		} else if r == '?' {
-			parts = append(parts, PART_UNKNOWN)
-		} else if r == ' ' {
-			checksumStart = i+1
-		}
-	}
-
-	var checksum []int
-	checkParts := strings.Split(line[checksumStart:], ",")
-	for _, s := range checkParts {
-		value, _ := strconv.ParseInt(s, 10, 32)
-		checksum = append(checksum, int(value))
+func parseUnfoldedRow(line aoc.SourceLine) (*Row, error) {
+	parts, checksum, err := parseRecord(line)
+	if err != nil {
+		return nil, err
 	}
 
 	var realparts []int
@@ -121,21 +28,17 @@ func parseRow(line string) *Row {
 		realchk = append(realchk, checksum...)
 	}
 
-	return makeRow(realparts, realchk)
+	return makeRow(realparts, realchk), nil
 }
 
-func computePossibles(row *Row) int64 {
-	if c, ok := cache[row.line]; ok {
-		return c
-	}
-
+// nextRows is one step of working out a row's arrangements. Either the row
+// is settled with 0 or 1 arrangements, or it has as many as the rows returned.
+func (row *Row) nextRows() ([]*Row, int64) {
 	if !row.isPossible() {
-		cache[row.line] = 0
-		return 0
+		return nil, 0
 	}
 	if len(row.parts) == 0 {
-		cache[row.line] = 1
-		return 1
+		return nil, 1
 	}
 
 	if row.parts[0] == PART_OPERATIONAL {
@@ -146,70 +49,66 @@ func computePossibles(row *Row) int64 {
 				break
 			}
 		}
-		v := computePossibles(makeRow(row.parts[next:], row.checksum))
-		cache[row.line] = v
-		return v
+		return []*Row{makeRow(row.parts[next:], row.checksum)}, 0
 	}
 	if row.parts[0] == PART_BROKEN {
 		if len(row.checksum) == 0 {
-			cache[row.line] = 0
-			return 0
+			return nil, 0
 		}
 		required := row.checksum[0]
 		if len(row.parts) < required {
-			cache[row.line] = 0
-			return 0
+			return nil, 0
 		}
 		for _, p := range row.parts[0:required] {
 			if p == PART_OPERATIONAL {
-				cache[row.line] = 0
-				return 0
+				return nil, 0
 			}
 		}
 		next := required
 		if len(row.parts) > required {
 			if row.parts[required] == PART_BROKEN {
-				cache[row.line] = 0
-				return 0
+				return nil, 0
 			}
 			next++
 		}
-		v := computePossibles(makeRow(row.parts[next:], row.checksum[1:]))
-		cache[row.line] = v
-		return v
+		return []*Row{makeRow(row.parts[next:], row.checksum[1:])}, 0
 	}
 
 	ifOperational := append([]int{PART_OPERATIONAL}, row.parts[1:]...)
 	ifBroken := append([]int{PART_BROKEN}, row.parts[1:]...)
-
-	a := computePossibles(makeRow(ifOperational, row.checksum))
-	b := computePossibles(makeRow(ifBroken, row.checksum))
-
-	cache[row.line] = a + b
-	return a + b
+	return []*Row{makeRow(ifOperational, row.checksum), makeRow(ifBroken, row.checksum)}, 0
 }
 
-func main() {
-	flag.Parse()
-
-	if *filePath == "" {
-		log.Fatalf("Must specify the file!")
+func computePossiblesCached(row *Row) (int64, error) {
+	if c, ok := cache[row.line]; ok {
+		return c, nil
 	}
 
-	contents, err := os.ReadFile(*filePath)
-	if err != nil {
-		log.Fatal(err)
+	next, total := row.nextRows()
+	for _, r := range next {
+		v, err := computePossiblesCached(r)
+		if err != nil {
+			return 0, err
+		}
+		total, err = checked.Add(total, v)
+		if err != nil {
+			return 0, err
+		}
 	}
+	cache[row.line] = total
+	return total, nil
+}
 
-	lines := strings.Split(string(contents), "\n")
-
-	var total int64
-	for _, line := range lines {
-		row := parseRow(line)
-		possibles := computePossibles(row)
-		log.Printf("Line %s has %d possibles", line, possibles)
-		total += possibles
+func computePossiblesBig(row *Row) *big.Int {
+	if c, ok := bigCache[row.line]; ok {
+		return c
 	}
 
-	log.Printf("Total: %d", total)
+	next, settled := row.nextRows()
+	total := big.NewInt(settled)
+	for _, r := range next {
+		total.Add(total, computePossiblesBig(r))
+	}
+	bigCache[row.line] = total
+	return total
 }
@@ -0,0 +1,113 @@
+package day12
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+const (
+	PART_UNKNOWN = iota
+	PART_OPERATIONAL
+	PART_BROKEN
+)
+
+type Row struct {
+	line string
+	parts []int
+	checksum []int
+}
+
+func makeRow(parts []int, checksum []int) *Row {
+	var partString []rune
+	for _, p := range parts {
+		if p == PART_UNKNOWN {
+			partString = append(partString, '?')
+		} else if p == PART_OPERATIONAL {
+			partString = append(partString, '.')
+		} else if p == PART_BROKEN {
+			partString = append(partString, '#')
+		}
+	}
+	partString = append(partString, ' ')
+
+	var cs []string
+	for _, c := range checksum {
+		cs = append(cs, fmt.Sprintf("%d", c))
+	}
+
+	line := string(partString) + strings.Join(cs, ",")
+	return &Row{line: line, parts: parts, checksum: checksum}
+}
+
+func (r *Row) isPossible() bool {
+	if len(r.parts) == 0 && len(r.checksum) == 0 {
+		return true
+	}
+
+	req := r.minRequired()
+	if (req + len(r.checksum) - 1) > len(r.parts) {
+		return false
+	}
+	numBroke := r.numMaybeDamaged()
+	if numBroke < req {
+		return false
+	}
+	return true
+}
+
+func (r *Row) minRequired() int {
+	if len(r.checksum) == 0 {
+		return 0
+	}
+
+	var val int
+	for _, c := range r.checksum {
+		val += c
+	}
+	return val
+}
+
+func (r *Row) numMaybeDamaged() int {
+	var op int
+	for _, p := range r.parts {
+		if p == PART_BROKEN || p == PART_UNKNOWN {
+			op++
+		}
+	}
+	return op
+}
+
+var springParts = map[rune]int {
+	'.': PART_OPERATIONAL,
+	'#': PART_BROKEN,
+	'?': PART_UNKNOWN,
+}
+
+// parseRecord reads the springs and the sizes of each group of damaged springs.
+func parseRecord(line aoc.SourceLine) ([]int, []int, error) {
+	springs, groups, found := line.Whole().TrimSpace().Cut(" ")
+	if !found {
+		return nil, nil, line.Errorf(line.End(), "a space and then the damaged group sizes")
+	}
+
+	var parts []int
+	for i, r := range springs.Text {
+		p, ok := springParts[r]
+		if !ok {
+			return nil, nil, line.Errorf(springs.Slice(i, i+1), "one of %q", ".#?")
+		}
+		parts = append(parts, p)
+	}
+
+	var checksum []int
+	for _, s := range groups.Split(",") {
+		value, err := line.Int(s, 10, 32)
+		if err != nil {
+			return nil, nil, err
+		}
+		checksum = append(checksum, int(value))
+	}
+	return parts, checksum, nil
+}
@@ -0,0 +1,94 @@
+package day12
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+	"github.com/HallM/aoc2023/checked"
+)
+
+type springSolver struct {
+	unfold bool
+	bigint bool
+}
+
+func init() {
+	aoc.Register(12, 1, &springSolver{})
+	aoc.Register(12, 2, &springSolver{unfold: true})
+}
+
+func (s *springSolver) RegisterFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&s.bigint, "bigint", false, "Use arbitrary precision instead of failing when the answer overflows an int64")
+}
+
+func (s *springSolver) Solve(contents string) (string, error) {
+	return s.SolveStream(strings.NewReader(contents))
+}
+
+func (s *springSolver) SolveStream(contents io.Reader) (string, error) {
+	var total int64
+	bigTotal := new(big.Int)
+	err := aoc.EachLine(contents, func(line string, n int) error {
+		if len(strings.TrimSpace(line)) == 0 {
+			return nil
+		}
+		source := aoc.SourceLine{Number: n, Text: line}
+
+		var row *Row
+		var err error
+		if s.unfold {
+			row, err = parseUnfoldedRow(source)
+		} else {
+			row, err = parseRow(source)
+		}
+		if err != nil {
+			return err
+		}
+
+		if s.bigint {
+			possibles := computePossiblesBig(makeRow(row.parts, row.checksum))
+			log.Printf("Line %s has %s possibles", line, possibles)
+			bigTotal.Add(bigTotal, possibles)
+		} else {
+			var possibles int64
+			if s.unfold {
+				possibles, err = computePossiblesCached(row)
+				if err != nil {
+					return fmt.Errorf("Line %d: %w", n, err)
+				}
+			} else {
+				possibles = int64(computePossibles(row, ""))
+			}
+			log.Printf("Line %s has %d possibles", line, possibles)
+			total, err = checked.Add(total, possibles)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Rows rarely share any arrangements, so don't let the caches grow with the input.
+		clear(cache)
+		clear(bigCache)
+		return nil
+	})
+	if errors.Is(err, checked.ErrOverflow) {
+		return "", fmt.Errorf("%w, try --bigint", err)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if s.bigint {
+		log.Printf("Total: %s", bigTotal)
+		return bigTotal.String(), nil
+	}
+	log.Printf("Total: %d", total)
+	return strconv.FormatInt(total, 10), nil
+}
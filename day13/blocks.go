@@ -0,0 +1,141 @@
+package day13
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+	"github.com/HallM/aoc2023/grid"
+)
+
+const (
+	IS_ASH = int64(iota)
+	IS_ROCK
+)
+
+var cellTypeMap = map[rune]int64 {
+	'.': IS_ASH,
+	'#': IS_ROCK,
+}
+
+const (
+	horizMultiplier = 100
+	vertMultiplier = 1
+)
+
+type Block struct {
+	lineNumber int
+
+	// bitmasks where 0=ash, 1=rock
+	// top/left is left-most bit, bottom/right is right-most bit
+	cols []int64
+	rows []int64
+}
+
+func (b *Block) print() {
+	log.Printf("Block at %d:", b.lineNumber)
+	log.Printf("  Rows:")
+	for y, r := range b.rows {
+		log.Printf("    row %d: %s", y+1, strconv.FormatInt(r, 2))
+	}
+	log.Printf("  Cols:")
+	for x, c := range b.cols {
+		log.Printf("    col %d: %s", x+1, strconv.FormatInt(c, 2))
+	}
+}
+
+// returns 0 if no vertical reflection
+// or number of columns to the left of the vertical reflection line
+func (b *Block) findVertical(exclude int) int {
+	return findReflection(b.cols, exclude)
+}
+
+func (b *Block) findHorizontal(exclude int) int {
+	return findReflection(b.rows, exclude)
+}
+
+func (b *Block) findScore(excludeV int, excludeH int) (int, int) {
+	return b.findVertical(excludeV), b.findHorizontal(excludeH)
+}
+
+func findReflection(arr []int64, exclude int) int {
+	for x, v := range arr[1:] {
+		if x == exclude-1 {
+			continue
+		}
+		if v == arr[x] {
+			allMatches := true
+			j := x+2
+			for i := x-1; i >= 0 && j < len(arr); i-- {
+				if arr[i] != arr[j] {
+					allMatches = false
+					break
+				}
+				j++
+			}
+			if allMatches {
+				return x+1
+			}
+		}
+	}
+	return 0
+}
+
+func parseBlock(block string, lineNumber int) (*Block, error) {
+	g, err := grid.ParseMap(block, cellTypeMap)
+	if err != nil {
+		// The grid counts its lines from the start of the block.
+		var perr *aoc.ParseError
+		if errors.As(err, &perr) {
+			perr.Line += lineNumber - 1
+		}
+		return nil, err
+	}
+
+	rows := make([]int64, g.Height)
+	cols := make([]int64, g.Width)
+
+	for y := range rows {
+		rows[y] = packBits(g.Row(y))
+	}
+	for x := range cols {
+		cols[x] = packBits(g.Column(x))
+	}
+	return &Block{lineNumber: lineNumber, rows: rows, cols: cols}, nil
+}
+
+// packBits turns a row or column into a bitmask with the first cell as the left-most bit.
+func packBits(cells []int64) int64 {
+	var mask int64
+	for _, c := range cells {
+		mask = (mask << 1) | c
+	}
+	return mask
+}
+
+func parseInput(contents string) ([]*Block, error) {
+	blocks := strings.Split(contents, "\n\n")
+	line := 1
+	var ret []*Block
+	for i, b := range blocks {
+		// extra blank lines between blocks, or at the end, aren't a block
+		if strings.TrimSpace(b) == "" {
+			line += len(strings.Split(b, "\n")) + 1
+			continue
+		}
+		log.Printf("parsing block %d at line %d", i+1, line)
+		block, err := parseBlock(b, line)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, block)
+		line += len(strings.Split(b, "\n")) + 1
+	}
+	if len(ret) == 0 {
+		start := aoc.SourceLine{Number: 1}
+		return nil, start.Errorf(start.End(), "at least one row")
+	}
+	return ret, nil
+}
@@ -1,74 +1,28 @@
-package main
+package day14
 
 import (
-	"flag"
 	"log"
-	"os"
+	"strconv"
 	"strings"
-)
-
-var filePath = flag.String("file", "part1test.txt", "File path")
-
-type Rock struct {
-	y int
-	isRound bool
-}
 
-type Platform struct {
-	cols [][]Rock
-	height int
-}
-
-func (p *Platform) computeLoad() int {
-	var load int
-	for _, rocks := range p.cols {
-		for _, r := range rocks {
-			if r.isRound {
-				load += p.height - r.y
-			}
-		}
-	}
-	return load
-}
-
-func parsePlatform(contents string) *Platform {
-	lines := strings.Split(contents, "\n")
-	height := len(lines)
-	width := len(lines[0])
-
-	cols := make([][]Rock, width)
-	colNextY := map[int]int{}
+	"github.com/HallM/aoc2023/aoc"
+)
 
-	for y, line := range lines {
-		for x, r := range line {
-			if r == 'O' {
-				cols[x] = append(cols[x], Rock{y: colNextY[x], isRound: true})
-				colNextY[x]++
-			} else if r == '#' {
-				colNextY[x] = y
-				cols[x] = append(cols[x], Rock{y: colNextY[x], isRound: false})
-				colNextY[x]++
-			}
-		}
-	}
-	return &Platform{cols: cols, height: height}
+func init() {
+	aoc.Register(14, 1, aoc.SolverFunc(solvePart1))
 }
 
-func main() {
-	flag.Parse()
+func solvePart1(contents string) (string, error) {
+	str := strings.ReplaceAll(contents, "\r", "")
 
-	if *filePath == "" {
-		log.Fatalf("Must specify the file!")
-	}
-
-	contents, err := os.ReadFile(*filePath)
+	// part 1 is only a single tilt to the north
+	platform, err := parsePlatform(str)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
 	}
-	str := strings.ReplaceAll(string(contents), "\r", "")
-
-	platform := parsePlatform(str)
+	platform.rotateNorth()
 	total := platform.computeLoad()
 
 	log.Printf("Sum: %d", total)
+	return strconv.Itoa(total), nil
 }
@@ -0,0 +1,141 @@
+package day14
+
+import (
+	"github.com/HallM/aoc2023/grid"
+)
+
+type Slot struct {
+	x int
+	y int
+	isRound bool
+	isSquare bool
+}
+
+type Platform struct {
+	*grid.Grid[Slot]
+}
+
+func slotToRune(s Slot) rune {
+	if s.isRound {
+		return 'O'
+	} else if s.isSquare {
+		return '#'
+	}
+	return '.'
+}
+
+func (p *Platform) computeHash() string {
+	return p.Render(slotToRune)
+}
+
+func (p *Platform) computeLoad() int {
+	var load int
+	for i, r := range p.Cells() {
+		if r.isRound {
+			y := i / p.Width
+			load += p.Height - y
+		}
+	}
+	return load
+}
+
+func (p *Platform) rotateNorth() {
+	cells := p.Cells()
+	colNextY := map[int]int{}
+
+	for i := range cells {
+		x := i % p.Width
+		y := i / p.Width
+
+		if cells[i].isRound {
+			moveY := colNextY[x]
+			cells[i].isRound = false
+			cells[moveY*p.Width + x].isRound = true
+			colNextY[x]++
+		} else if cells[i].isSquare {
+			colNextY[x] = y+1
+		}
+	}
+}
+
+func (p *Platform) rotateSouth() {
+	cells := p.Cells()
+	colNextY := map[int]int{}
+	for x := 0; x < p.Width; x++ {
+		colNextY[x] = p.Height - 1
+	}
+
+	for i := len(cells)-1; i >= 0; i-- {
+		x := i % p.Width
+		y := i / p.Width
+
+		if cells[i].isRound {
+			moveY := colNextY[x]
+			cells[i].isRound = false
+			cells[moveY*p.Width + x].isRound = true
+			colNextY[x]--
+		} else if cells[i].isSquare {
+			colNextY[x] = y-1
+		}
+	}
+}
+
+
+func (p *Platform) rotateWest() {
+	cells := p.Cells()
+	rowNextX := 0
+
+	for i := range cells {
+		x := i % p.Width
+		y := i / p.Width
+		if x == 0 {
+			rowNextX = 0
+		}
+
+		if cells[i].isRound {
+			moveX := rowNextX
+			cells[i].isRound = false
+			cells[y*p.Width + moveX].isRound = true
+			rowNextX++
+		} else if cells[i].isSquare {
+			rowNextX = x+1
+		}
+	}
+}
+
+func (p *Platform) rotateEast() {
+	cells := p.Cells()
+	rowNextX := p.Width - 1
+
+	for i := len(cells)-1; i >= 0; i-- {
+		x := i % p.Width
+		y := i / p.Width
+		if x == p.Width - 1 {
+			rowNextX = p.Width - 1
+		}
+
+		if cells[i].isRound {
+			moveX := rowNextX
+			cells[i].isRound = false
+			cells[y*p.Width + moveX].isRound = true
+			rowNextX--
+		} else if cells[i].isSquare {
+			rowNextX = x-1
+		}
+	}
+}
+
+func parsePlatform(contents string) (*Platform, error) {
+	g, err := grid.Parse(contents, func(r rune) (Slot, bool) {
+		if r == 'O' {
+			return Slot{isRound: true}, true
+		} else if r == '#' {
+			return Slot{isSquare: true}, true
+		}
+		return Slot{}, r == '.'
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Platform{g}, nil
+}
@@ -1,12 +1,13 @@
-package main
+package day15
 
 import (
-	"flag"
+	"io"
 	"log"
-	"os"
-)
+	"strconv"
+	"strings"
 
-var filePath = flag.String("file", "part1test.txt", "File path")
+	"github.com/HallM/aoc2023/aoc"
+)
 
 func computeHash(s []byte) int {
 	var hash int
@@ -18,33 +19,52 @@ func computeHash(s []byte) int {
 	return hash
 }
 
-func main() {
-	flag.Parse()
+func init() {
+	aoc.Register(15, 1, aoc.StreamFunc(solvePart1))
+}
 
-	if *filePath == "" {
-		log.Fatalf("Must specify the file!")
+// eachStep calls fn with each comma separated step, ignoring newlines as the
+// puzzle says to. The whole sequence is one huge line, so each step only knows
+// the column it starts at.
+func eachStep(input io.Reader, fn func(step aoc.SourceLine) error) error {
+	line, column := 1, 1
+	advance := func(s string) {
+		for _, c := range s {
+			if c == '\n' {
+				line++
+				column = 1
+			} else {
+				column++
+			}
+		}
 	}
 
-	contents, err := os.ReadFile(*filePath)
-	if err != nil {
-		log.Fatal(err)
-	}
+	return aoc.Scan(input, aoc.SplitOn(','), func(token string, n int) error {
+		text := strings.TrimLeft(token, "\r\n")
+		advance(token[:len(token)-len(text)])
 
-	total := 0
-	start := 0
-	for i, c := range contents {
-		if c == ',' {
-			s := contents[start:i]
-			hash := computeHash(s)
-			log.Printf("%s hash is %d", string(s), hash)
-			start = i+1
-			total += hash
+		step := aoc.SourceLine{
+			Number: line,
+			Text: strings.ReplaceAll(strings.ReplaceAll(text, "\r", ""), "\n", ""),
+			Start: column,
 		}
+		advance(text + ",")
+		return fn(step)
+	})
+}
+
+func solvePart1(input io.Reader) (string, error) {
+	total := 0
+	err := eachStep(input, func(step aoc.SourceLine) error {
+		hash := computeHash([]byte(step.Text))
+		log.Printf("%s hash is %d", step.Text, hash)
+		total += hash
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
-	s := contents[start:]
-	hash := computeHash(s)
-	log.Printf("%s hash is %d", string(s), hash);
-	total += hash
 
 	log.Printf("Sum: %d", total)
+	return strconv.Itoa(total), nil
 }
@@ -1,14 +1,14 @@
-package main
+package day15
 
 import (
-	"flag"
 	"fmt"
+	"io"
 	"log"
+	"strconv"
 	"strings"
-	"os"
-)
 
-var filePath = flag.String("file", "part1test.txt", "File path")
+	"github.com/HallM/aoc2023/aoc"
+)
 
 type Hashmap struct {
 	boxes []*List
@@ -59,9 +59,12 @@ func (h *Hashmap) print() {
 	}
 }
 
-func (h *Hashmap) runInstruction(s []byte) {
-	instr := computeInstruction(s)
-	log.Printf("After %s = %s hash is %d - %v / %v", string(s), instr.label, instr.hash, instr.removeLens, instr.setFocal)
+func (h *Hashmap) runInstruction(step aoc.SourceLine) error {
+	instr, err := computeInstruction(step)
+	if err != nil {
+		return err
+	}
+	log.Printf("After %s = %s hash is %d - %v / %v", step.Text, instr.label, instr.hash, instr.removeLens, instr.setFocal)
 
 	box := h.boxes[instr.hash]
 
@@ -106,6 +109,7 @@ func (h *Hashmap) runInstruction(s []byte) {
 	}
 
 	// h.print()
+	return nil
 }
 
 func (h *Hashmap) computeFocusPower() int {
@@ -126,48 +130,45 @@ func (h *Hashmap) computeFocusPower() int {
 	return power
 }
 
-func computeInstruction(s []byte) *Instruction {
+func computeInstruction(step aoc.SourceLine) (*Instruction, error) {
+	s := []byte(step.Text)
 	var hash int
 	for i, c := range s {
 		if c == 45 {
 			// "-"
-			return &Instruction{label: string(s[0:i]), hash: hash, removeLens: true, setFocal: 0}
+			if i+1 != len(s) {
+				return nil, step.Errorf(step.Whole().From(i+1), "nothing after %q", "-")
+			}
+			return &Instruction{label: string(s[0:i]), hash: hash, removeLens: true, setFocal: 0}, nil
 		} else if c == 61 {
 			// "="
+			if i+2 != len(s) || s[i+1] < '1' || s[i+1] > '9' {
+				return nil, step.Errorf(step.Whole().From(i+1), "a focal length from 1 to 9")
+			}
 			focal := int(s[i+1]) - 48
-			return &Instruction{label: string(s[0:i]), hash: hash, removeLens: false, setFocal: focal}
+			return &Instruction{label: string(s[0:i]), hash: hash, removeLens: false, setFocal: focal}, nil
 		}
 
 		hash += int(c)
 		hash = hash * 17
 		hash = (hash & 0xFF)
 	}
-	return nil
+	return nil, step.Errorf(step.End(), "%q or %q", "-", "=")
 }
 
-func main() {
-	flag.Parse()
-
-	if *filePath == "" {
-		log.Fatalf("Must specify the file!")
-	}
-
-	contents, err := os.ReadFile(*filePath)
-	if err != nil {
-		log.Fatal(err)
-	}
+func init() {
+	aoc.Register(15, 2, aoc.StreamFunc(solvePart2))
+}
 
+func solvePart2(input io.Reader) (string, error) {
 	hashmap := makeHashmap()
 
-	start := 0
-	for i, c := range contents {
-		if c == ',' {
-			hashmap.runInstruction(contents[start:i])
-			start = i+1
-		}
+	err := eachStep(input, hashmap.runInstruction)
+	if err != nil {
+		return "", err
 	}
-	hashmap.runInstruction(contents[start:])
 
 	total := hashmap.computeFocusPower()
 	log.Printf("Sum: %d", total)
+	return strconv.Itoa(total), nil
 }
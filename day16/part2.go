@@ -1,240 +1,137 @@
-package main
+package day16
 
 import (
 	"flag"
+	"fmt"
 	"log"
-	"os"
+	"runtime"
+	"strconv"
 	"strings"
-)
-
-var filePath = flag.String("file", "part1test.txt", "File path")
-
-const (
-	CELL_EMPTY = iota
-	CELL_SLASH
-	CELL_BACKSLASH
-	CELL_SPLITVERT
-	CELL_SPLITHORIZ
-)
+	"sync"
 
-const (
-	DIRECTION_RIGHT = iota
-	DIRECTION_UP
-	DIRECTION_LEFT
-	DIRECTION_DOWN
+	"github.com/HallM/aoc2023/aoc"
 )
 
-var celltypes = map[rune]int {
-	'.': CELL_EMPTY,
-	'/': CELL_SLASH,
-	'\\': CELL_BACKSLASH,
-	'|': CELL_SPLITVERT,
-	'-': CELL_SPLITHORIZ,
-}
-
-type Cell struct {
-	kind int
-	isEnergized bool
-}
-
-type Room struct {
-	grid []Cell
-	width int
-	height int
-}
-
-type Laser struct {
-	x int
-	y int
-	direction int
-}
-
-// mapping kind > input direction > output directions
-var laserMovement = map[int]map[int][]int {
-	CELL_EMPTY: map[int][]int {
-		DIRECTION_RIGHT: []int{ DIRECTION_RIGHT },
-		DIRECTION_UP: []int{ DIRECTION_UP },
-		DIRECTION_LEFT: []int{ DIRECTION_LEFT },
-		DIRECTION_DOWN: []int{ DIRECTION_DOWN },
-	},
-	CELL_SLASH: map[int][]int {
-		DIRECTION_RIGHT: []int{ DIRECTION_UP },
-		DIRECTION_UP: []int{ DIRECTION_RIGHT },
-		DIRECTION_LEFT: []int{ DIRECTION_DOWN },
-		DIRECTION_DOWN: []int{ DIRECTION_LEFT },
-	},
-	CELL_BACKSLASH: map[int][]int {
-		DIRECTION_RIGHT: []int{ DIRECTION_DOWN },
-		DIRECTION_UP: []int{ DIRECTION_LEFT },
-		DIRECTION_LEFT: []int{ DIRECTION_UP },
-		DIRECTION_DOWN: []int{ DIRECTION_RIGHT },
-	},
-	CELL_SPLITVERT: map[int][]int {
-		DIRECTION_RIGHT: []int{ DIRECTION_UP, DIRECTION_DOWN },
-		DIRECTION_UP: []int{ DIRECTION_UP },
-		DIRECTION_LEFT: []int{ DIRECTION_UP, DIRECTION_DOWN },
-		DIRECTION_DOWN: []int{ DIRECTION_DOWN },
-	},
-	CELL_SPLITHORIZ: map[int][]int {
-		DIRECTION_RIGHT: []int{ DIRECTION_RIGHT },
-		DIRECTION_UP: []int{ DIRECTION_LEFT, DIRECTION_RIGHT },
-		DIRECTION_LEFT: []int{ DIRECTION_LEFT },
-		DIRECTION_DOWN: []int{ DIRECTION_LEFT, DIRECTION_RIGHT },
-	},
-}
-
-func (r *Room) energizeCell(x, y int) {
-	r.grid[y*r.width+x].isEnergized = true
-}
-
-func (r *Room) shootLaser(startX, startY int, startDirection int) {
-	var lasers []*Laser
-
-	seen := map[int]map[int]bool{}
-	for i := range r.grid {
-		seen[i] = map[int]bool{}
+// edgeStarts are all the places a laser can enter the room from, pointing inwards.
+func (r *Room) edgeStarts() []Laser {
+	var starts []Laser
+	// do left to right along top side pointing down
+	for x := 0; x < r.Width; x++ {
+		starts = append(starts, Laser{x: x, y: 0, direction: DIRECTION_DOWN})
 	}
-
-	nextDirections := laserMovement[r.grid[startY*r.width+startX].kind][startDirection]
-	for _, d := range nextDirections {
-		lasers = append(lasers, &Laser{ x: startX, y: startY, direction: d })
+	// do left to right along bottom side pointing up
+	for x := 0; x < r.Width; x++ {
+		starts = append(starts, Laser{x: x, y: r.Height-1, direction: DIRECTION_UP})
 	}
-
-	step := 0
-	for len(lasers) > 0 {
-		step++
-		var next []*Laser
-		for _, l := range lasers {
-			r.energizeCell(l.x, l.y)
-
-			nextX := l.x
-			nextY := l.y
-			if l.direction == DIRECTION_RIGHT {
-				nextX = l.x + 1
-			} else if l.direction == DIRECTION_LEFT {
-				nextX = l.x - 1
-			} else if l.direction == DIRECTION_DOWN {
-				nextY = l.y + 1
-			} else if l.direction == DIRECTION_UP {
-				nextY = l.y - 1
-			}
-			gridcoords := nextY*r.width + nextX
-			if nextX < 0 || nextY < 0 || nextX >= r.width || nextY >= r.height || seen[gridcoords][l.direction] {
-				continue
-			}
-			seen[gridcoords][l.direction] = true
-			nextDirections := laserMovement[r.grid[gridcoords].kind][l.direction]
-			for _, d := range nextDirections {
-				next = append(next, &Laser{ x: nextX, y: nextY, direction: d })
-			}
-		}
-		lasers = next
+	// do top to bottom along left side pointing right
+	for y := 0; y < r.Height; y++ {
+		starts = append(starts, Laser{x: 0, y: y, direction: DIRECTION_RIGHT})
 	}
+	// do top to bottom along right side pointing left
+	for y := 0; y < r.Height; y++ {
+		starts = append(starts, Laser{x: r.Width-1, y: y, direction: DIRECTION_LEFT})
+	}
+	return starts
 }
 
-func (r *Room) energizedCount() int {
-	total := 0
-	for _, c := range r.grid {
-		if c.isEnergized {
-			total++
-		}
+// countAll runs count for each start across a pool of workers, and the counts
+// come back in the order of starts. Each worker makes its own count func so it
+// can keep its own state.
+func countAll(starts []Laser, workers int, newCount func() func(start Laser) int) []int {
+	counts := make([]int, len(starts))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			count := newCount()
+			for i := range jobs {
+				counts[i] = count(starts[i])
+			}
+		}()
 	}
-	return total
-}
 
-func (r *Room) reset() {
-	for i := range r.grid {
-		r.grid[i].isEnergized = false
+	for i := range starts {
+		jobs <- i
 	}
+	close(jobs)
+	wg.Wait()
+	return counts
 }
 
-func (r *Room) print() {
-	i := 0
-	for y := 0; y < r.height; y++ {
-		var row []rune
-		for x := 0; x < r.width; x++ {
-			if r.grid[i].isEnergized {
-				row = append(row, '#')
-			} else {
-				row = append(row, '.')
-			}
-			i++
+// simulateAll follows every beam cell by cell, each worker with its own beamState.
+func (r *Room) simulateAll(starts []Laser, workers int) []int {
+	return countAll(starts, workers, func() func(start Laser) int {
+		beam := newBeamState(r)
+		return func(start Laser) int {
+			beam.reset()
+			r.shootLaser(beam, start)
+			return beam.energizedCount()
 		}
-		log.Printf("%s", string(row))
-	}
+	})
 }
 
-func parseRoom(contents string) *Room {
-	lines := strings.Split(contents, "\n")
-	height := len(lines)
-	width := len(lines[0])
+// segmentAll builds the segment graph once, then every worker reads from it.
+func (r *Room) segmentAll(starts []Laser, workers int) []int {
+	graph := newSegmentGraph(r, starts)
+	log.Printf("%d segments in %d components", len(graph.segments), len(graph.components))
+	return countAll(starts, workers, func() func(start Laser) int {
+		return graph.energizedCount
+	})
+}
 
-	grid := make([]Cell, width*height)
+type edgeSolver struct {
+	workers int
+	simulate bool
+	renderOptions
+}
 
-	i := 0
-	for _, line := range lines {
-		for _, r := range line {
-			kind := celltypes[r]
-			grid[i].kind = kind
-			grid[i].isEnergized = false
-			i++
-		}
-	}
-	return &Room{grid: grid, width: width, height: height}
+func init() {
+	aoc.Register(16, 2, &edgeSolver{workers: runtime.NumCPU()})
 }
 
-func main() {
-	flag.Parse()
+func (s *edgeSolver) RegisterFlags(fs *flag.FlagSet) {
+	fs.IntVar(&s.workers, "workers", s.workers, "How many entry points to evaluate at once")
+	fs.BoolVar(&s.simulate, "simulate", false, "Follow every beam cell by cell instead of using the segment graph")
+	s.registerFlags(fs)
+}
 
-	if *filePath == "" {
-		log.Fatalf("Must specify the file!")
+func (s *edgeSolver) Solve(contents string) (string, error) {
+	if s.workers < 1 {
+		return "", fmt.Errorf("Need at least 1 worker, got %d", s.workers)
 	}
 
-	contents, err := os.ReadFile(*filePath)
+	str := strings.ReplaceAll(contents, "\r", "")
+
+	room, err := parseRoom(str)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
 	}
-	str := strings.ReplaceAll(string(contents), "\r", "")
-
-	room := parseRoom(str)
-	var maxTotal int
 
-	// do left to right along top side pointing down
-	for x := 0; x < room.width; x++ {
-		room.shootLaser(x, 0, DIRECTION_DOWN)
-		total := room.energizedCount()
-		if total > maxTotal {
-			maxTotal = total
-		}
-		room.reset()
+	starts := room.edgeStarts()
+	var counts []int
+	if s.simulate {
+		counts = room.simulateAll(starts, s.workers)
+	} else {
+		counts = room.segmentAll(starts, s.workers)
 	}
-	// do left to right along bottom side pointing up
-	for x := 0; x < room.width; x++ {
-		room.shootLaser(x, room.height-1, DIRECTION_UP)
-		total := room.energizedCount()
-		if total > maxTotal {
-			maxTotal = total
-		}
-		room.reset()
-	}
-	// do top to bottom along left side pointing right
-	for y := 0; y < room.height; y++ {
-		room.shootLaser(0, y, DIRECTION_RIGHT)
-		total := room.energizedCount()
-		if total > maxTotal {
-			maxTotal = total
-		}
-		room.reset()
-	}
-	// do top to bottom along right side pointing left
-	for y := 0; y < room.height; y++ {
-		room.shootLaser(room.width-1, y, DIRECTION_LEFT)
-		total := room.energizedCount()
+
+	// The earliest start wins a tie, so the answer never depends on which worker finished first.
+	var maxTotal int
+	var best Laser
+	for i, total := range counts {
 		if total > maxTotal {
 			maxTotal = total
+			best = starts[i]
 		}
-		room.reset()
 	}
 
+	log.Printf("Best start is (%d, %d) going %d", best.x, best.y, best.direction)
+	if err := s.writeRender(room, best); err != nil {
+		return "", err
+	}
 	log.Printf("Sum: %d", maxTotal)
+	return strconv.Itoa(maxTotal), nil
 }
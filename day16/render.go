@@ -0,0 +1,244 @@
+package day16
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"os"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+// renderOptions are the flags shared by both parts for drawing the beam.
+type renderOptions struct {
+	render string
+	out    string
+}
+
+func (o *renderOptions) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.render, "render", "", "Draw the beam: png for a heatmap, gif for an animation")
+	fs.StringVar(&o.out, "out", "", "File to write the render to (default stdout)")
+}
+
+// beamRecording is what a single laser did, step by step.
+type beamRecording struct {
+	// how many beams crossed each tile
+	crossings []int
+	// the tiles at the front of the beam for each step
+	fronts [][]int
+}
+
+func (r *Room) recordLaser(start Laser) *beamRecording {
+	rec := &beamRecording{crossings: make([]int, len(r.Cells()))}
+	beam := newBeamState(r)
+	beam.onStep = func(step int, lasers []*Laser) {
+		front := make([]int, 0, len(lasers))
+		for _, l := range lasers {
+			i := r.Index(l.x, l.y)
+			rec.crossings[i]++
+			front = append(front, i)
+		}
+		rec.fronts = append(rec.fronts, front)
+	}
+	r.shootLaser(beam, start)
+	return rec
+}
+
+func (o *renderOptions) writeRender(r *Room, start Laser) error {
+	if o.render == "" {
+		return nil
+	}
+	if o.render != "png" && o.render != "gif" {
+		return fmt.Errorf("Unknown render %q, expected png or gif", o.render)
+	}
+	if (o.out == "" || o.out == "-") && stdoutIsTerminal() {
+		return fmt.Errorf("Not writing a %s to the terminal, use --out or redirect stdout", o.render)
+	}
+
+	rec := r.recordLaser(start)
+
+	out, err := aoc.CreateOutput(o.out)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if o.render == "png" {
+		return png.Encode(out, r.renderHeatmap(rec))
+	}
+	return gif.EncodeAll(out, r.renderAnimation(rec))
+}
+
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// tileSize picks how many pixels a tile gets so the image stays around maxPixels across.
+func (r *Room) tileSize(maxPixels, largest int) int {
+	size := maxPixels / max(r.Width, r.Height, 1)
+	return max(1, min(size, largest))
+}
+
+var (
+	backgroundColor = color.RGBA{20, 20, 30, 255}
+	opticColor      = color.RGBA{200, 200, 200, 255}
+	heatStops       = []color.RGBA{
+		{96, 0, 0, 255},
+		{224, 32, 0, 255},
+		{255, 224, 0, 255},
+		{255, 255, 255, 255},
+	}
+)
+
+// heatColor blends along heatStops, with t from 0 for the least crossed to 1 for the most.
+func heatColor(t float64) color.RGBA {
+	pos := t * float64(len(heatStops)-1)
+	i := min(int(pos), len(heatStops)-2)
+	frac := pos - float64(i)
+	a, b := heatStops[i], heatStops[i+1]
+	blend := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*frac)
+	}
+	return color.RGBA{blend(a.R, b.R), blend(a.G, b.G), blend(a.B, b.B), 255}
+}
+
+// onOptic is whether a pixel within a tile of the given size is part of the drawn optic.
+func onOptic(kind, px, py, size int) bool {
+	if size < 3 {
+		return false
+	}
+	switch kind {
+	case CELL_SLASH:
+		return px+py == size-1
+	case CELL_BACKSLASH:
+		return px == py
+	case CELL_SPLITVERT:
+		return px == size/2
+	case CELL_SPLITHORIZ:
+		return py == size/2
+	}
+	return false
+}
+
+// fillTile colors every pixel of a tile, drawing its optic over the top.
+func (r *Room) fillTile(cell, size int, set func(x, y int, optic bool)) {
+	x, y := cell%r.Width, cell/r.Width
+	kind := r.Cells()[cell].kind
+	for py := 0; py < size; py++ {
+		for px := 0; px < size; px++ {
+			set(x*size+px, y*size+py, onOptic(kind, px, py, size))
+		}
+	}
+}
+
+func (r *Room) renderHeatmap(rec *beamRecording) *image.RGBA {
+	size := r.tileSize(1200, 8)
+	img := image.NewRGBA(image.Rect(0, 0, r.Width*size, r.Height*size))
+
+	most := 0
+	for _, c := range rec.crossings {
+		most = max(most, c)
+	}
+
+	for cell, c := range rec.crossings {
+		fill := backgroundColor
+		if c > 0 {
+			t := 0.0
+			if most > 1 {
+				t = float64(c-1) / float64(most-1)
+			}
+			fill = heatColor(t)
+		}
+		r.fillTile(cell, size, func(x, y int, optic bool) {
+			if optic {
+				img.SetRGBA(x, y, opticColor)
+			} else {
+				img.SetRGBA(x, y, fill)
+			}
+		})
+	}
+	return img
+}
+
+const (
+	FRAME_BACKGROUND = iota
+	FRAME_TRAIL
+	FRAME_FRONT
+	FRAME_OPTIC
+)
+
+var framePalette = color.Palette{
+	FRAME_BACKGROUND: backgroundColor,
+	FRAME_TRAIL:      color.RGBA{120, 30, 0, 255},
+	FRAME_FRONT:      color.RGBA{255, 220, 0, 255},
+	FRAME_OPTIC:      opticColor,
+}
+
+// maxFrames caps how long the animation gets; longer beams advance several steps a frame.
+const maxFrames = 200
+
+// renderAnimation draws the room once, then each frame only redraws the tiles
+// that changed: the front of the beam lit up, and the last front fading into
+// the trail of everything it has energized so far.
+func (r *Room) renderAnimation(rec *beamRecording) *gif.GIF {
+	size := r.tileSize(600, 6)
+	bounds := image.Rect(0, 0, r.Width*size, r.Height*size)
+
+	canvas := image.NewPaletted(bounds, framePalette)
+	for cell := range r.Cells() {
+		r.fillTile(cell, size, func(x, y int, optic bool) {
+			if optic {
+				canvas.SetColorIndex(x, y, FRAME_OPTIC)
+			}
+		})
+	}
+	var changed image.Rectangle
+	paint := func(cells []int, index uint8) {
+		for _, cell := range cells {
+			x, y := cell%r.Width, cell/r.Width
+			changed = changed.Union(image.Rect(x*size, y*size, (x+1)*size, (y+1)*size))
+			r.fillTile(cell, size, func(x, y int, optic bool) {
+				if !optic {
+					canvas.SetColorIndex(x, y, index)
+				}
+			})
+		}
+	}
+
+	first := image.NewPaletted(bounds, framePalette)
+	copy(first.Pix, canvas.Pix)
+	anim := &gif.GIF{
+		Image:    []*image.Paletted{first},
+		Delay:    []int{5},
+		Disposal: []byte{gif.DisposalNone},
+		Config:   image.Config{ColorModel: framePalette, Width: bounds.Dx(), Height: bounds.Dy()},
+	}
+
+	perFrame := (len(rec.fronts) + maxFrames - 1) / maxFrames
+	var previous []int
+	for start := 0; start < len(rec.fronts); start += perFrame {
+		end := min(start+perFrame, len(rec.fronts))
+		changed = image.Rectangle{}
+		paint(previous, FRAME_TRAIL)
+		for _, front := range rec.fronts[start : end-1] {
+			paint(front, FRAME_TRAIL)
+		}
+		previous = rec.fronts[end-1]
+		paint(previous, FRAME_FRONT)
+		if changed.Empty() {
+			continue
+		}
+
+		frame := image.NewPaletted(changed, framePalette)
+		draw.Draw(frame, changed, canvas, changed.Min, draw.Src)
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, 5)
+		anim.Disposal = append(anim.Disposal, gif.DisposalNone)
+	}
+	return anim
+}
@@ -0,0 +1,195 @@
+package day16
+
+import (
+	"log"
+
+	"github.com/HallM/aoc2023/aoc"
+	"github.com/HallM/aoc2023/grid"
+)
+
+const (
+	CELL_EMPTY = iota
+	CELL_SLASH
+	CELL_BACKSLASH
+	CELL_SPLITVERT
+	CELL_SPLITHORIZ
+)
+
+const (
+	DIRECTION_RIGHT = iota
+	DIRECTION_UP
+	DIRECTION_LEFT
+	DIRECTION_DOWN
+)
+
+var celltypes = map[rune]int {
+	'.': CELL_EMPTY,
+	'/': CELL_SLASH,
+	'\\': CELL_BACKSLASH,
+	'|': CELL_SPLITVERT,
+	'-': CELL_SPLITHORIZ,
+}
+
+type Cell struct {
+	kind int
+}
+
+type Room struct {
+	*grid.Grid[Cell]
+}
+
+var laserOffsets = map[int]grid.Point {
+	DIRECTION_RIGHT: grid.East,
+	DIRECTION_UP: grid.North,
+	DIRECTION_LEFT: grid.West,
+	DIRECTION_DOWN: grid.South,
+}
+
+type Laser struct {
+	x int
+	y int
+	direction int
+}
+
+// mapping kind > input direction > output directions
+var laserMovement = map[int]map[int][]int {
+	CELL_EMPTY: map[int][]int {
+		DIRECTION_RIGHT: []int{ DIRECTION_RIGHT },
+		DIRECTION_UP: []int{ DIRECTION_UP },
+		DIRECTION_LEFT: []int{ DIRECTION_LEFT },
+		DIRECTION_DOWN: []int{ DIRECTION_DOWN },
+	},
+	CELL_SLASH: map[int][]int {
+		DIRECTION_RIGHT: []int{ DIRECTION_UP },
+		DIRECTION_UP: []int{ DIRECTION_RIGHT },
+		DIRECTION_LEFT: []int{ DIRECTION_DOWN },
+		DIRECTION_DOWN: []int{ DIRECTION_LEFT },
+	},
+	CELL_BACKSLASH: map[int][]int {
+		DIRECTION_RIGHT: []int{ DIRECTION_DOWN },
+		DIRECTION_UP: []int{ DIRECTION_LEFT },
+		DIRECTION_LEFT: []int{ DIRECTION_UP },
+		DIRECTION_DOWN: []int{ DIRECTION_RIGHT },
+	},
+	CELL_SPLITVERT: map[int][]int {
+		DIRECTION_RIGHT: []int{ DIRECTION_UP, DIRECTION_DOWN },
+		DIRECTION_UP: []int{ DIRECTION_UP },
+		DIRECTION_LEFT: []int{ DIRECTION_UP, DIRECTION_DOWN },
+		DIRECTION_DOWN: []int{ DIRECTION_DOWN },
+	},
+	CELL_SPLITHORIZ: map[int][]int {
+		DIRECTION_RIGHT: []int{ DIRECTION_RIGHT },
+		DIRECTION_UP: []int{ DIRECTION_LEFT, DIRECTION_RIGHT },
+		DIRECTION_LEFT: []int{ DIRECTION_LEFT },
+		DIRECTION_DOWN: []int{ DIRECTION_LEFT, DIRECTION_RIGHT },
+	},
+}
+
+// beamState is what a single run of the laser marks, so that any number of
+// runs can share the same Room.
+type beamState struct {
+	energized *grid.Grid[bool]
+	// a bit for each direction a laser has already entered the cell going
+	seen []uint8
+	// onStep, if set, sees the front of the beam at every step
+	onStep func(step int, lasers []*Laser)
+}
+
+func newBeamState(r *Room) *beamState {
+	return &beamState{
+		energized: grid.New[bool](r.Width, r.Height),
+		seen: make([]uint8, r.Width * r.Height),
+	}
+}
+
+func (b *beamState) energizeCell(x, y int) {
+	b.energized.Set(x, y, true)
+}
+
+func (r *Room) shootLaser(b *beamState, start Laser) {
+	var lasers []*Laser
+
+	nextDirections := laserMovement[r.Get(start.x, start.y).kind][start.direction]
+	for _, d := range nextDirections {
+		lasers = append(lasers, &Laser{ x: start.x, y: start.y, direction: d })
+	}
+
+	step := 0
+	for len(lasers) > 0 {
+		step++
+		if b.onStep != nil {
+			b.onStep(step, lasers)
+		}
+		var next []*Laser
+		for _, l := range lasers {
+			b.energizeCell(l.x, l.y)
+
+			nextX := l.x
+			nextY := l.y
+			if l.direction == DIRECTION_RIGHT {
+				nextX = l.x + 1
+			} else if l.direction == DIRECTION_LEFT {
+				nextX = l.x - 1
+			} else if l.direction == DIRECTION_DOWN {
+				nextY = l.y + 1
+			} else if l.direction == DIRECTION_UP {
+				nextY = l.y - 1
+			}
+			if !r.InBounds(nextX, nextY) {
+				continue
+			}
+			gridcoords := r.Index(nextX, nextY)
+			if b.seen[gridcoords] & (1 << l.direction) != 0 {
+				continue
+			}
+			b.seen[gridcoords] |= 1 << l.direction
+			nextDirections := laserMovement[r.Get(nextX, nextY).kind][l.direction]
+			for _, d := range nextDirections {
+				next = append(next, &Laser{ x: nextX, y: nextY, direction: d })
+			}
+		}
+		lasers = next
+	}
+}
+
+func (b *beamState) energizedCount() int {
+	total := 0
+	for _, c := range b.energized.Cells() {
+		if c {
+			total++
+		}
+	}
+	return total
+}
+
+func (b *beamState) reset() {
+	clear(b.energized.Cells())
+	clear(b.seen)
+}
+
+func (b *beamState) print() {
+	lines := b.energized.Lines(func(energized bool) rune {
+		if energized {
+			return '#'
+		}
+		return '.'
+	})
+	for _, line := range lines {
+		log.Printf("%s", line)
+	}
+}
+
+func parseRoom(contents string) (*Room, error) {
+	g, err := grid.Parse(contents, func(r rune) (Cell, bool) {
+		kind, ok := celltypes[r]
+		return Cell{kind: kind}, ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	if g.Height == 0 {
+		start := aoc.SourceLine{Number: 1}
+		return nil, start.Errorf(start.End(), "at least one row")
+	}
+	return &Room{g}, nil
+}
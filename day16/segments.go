@@ -0,0 +1,239 @@
+package day16
+
+import (
+	"math/bits"
+)
+
+// segment is a straight run of the beam from where it leaves a cell up to and
+// including the next optic that turns or splits it, or the edge of the room.
+type segment struct {
+	tiles []int
+	next  []int
+}
+
+// segmentGraph links every segment a beam from the edge can reach. Beams loop
+// back on themselves, so the segments are condensed into strongly connected
+// components. Every component's energized tiles are built from the bitsets
+// already cached for the components it leads to.
+type segmentGraph struct {
+	room     *Room
+	segments []segment
+	// segment id by cell index*4 + direction, or -1 if not reached
+	ids []int
+	// each start gets a segment of its own with no tiles, leading to the
+	// segments it shoots down
+	entries map[Laser]int
+
+	component []int
+	// components in the order they were finished, so each comes after all it leads to
+	components [][]int
+	counts     []int
+}
+
+func (r *Room) turns(cell int, direction int) bool {
+	out := laserMovement[r.Cells()[cell].kind][direction]
+	return len(out) != 1 || out[0] != direction
+}
+
+// segmentFrom is the id of the segment leaving cell going direction, walking it if it's new.
+func (g *segmentGraph) segmentFrom(cell int, direction int) int {
+	key := cell*4 + direction
+	if id := g.ids[key]; id >= 0 {
+		return id
+	}
+
+	id := len(g.segments)
+	g.ids[key] = id
+	g.segments = append(g.segments, segment{})
+
+	r := g.room
+	offset := laserOffsets[direction]
+	x, y := cell%r.Width, cell/r.Width
+	tiles := []int{cell}
+	var next []int
+	for {
+		x, y = x+offset.X, y+offset.Y
+		if !r.InBounds(x, y) {
+			break
+		}
+		c := r.Index(x, y)
+		tiles = append(tiles, c)
+		if r.turns(c, direction) {
+			for _, d := range laserMovement[r.Cells()[c].kind][direction] {
+				next = append(next, g.segmentFrom(c, d))
+			}
+			break
+		}
+	}
+
+	g.segments[id] = segment{tiles: tiles, next: next}
+	return id
+}
+
+func newSegmentGraph(r *Room, starts []Laser) *segmentGraph {
+	g := &segmentGraph{room: r, ids: make([]int, len(r.Cells())*4), entries: map[Laser]int{}}
+	for i := range g.ids {
+		g.ids[i] = -1
+	}
+	for _, start := range starts {
+		cell := r.Index(start.x, start.y)
+		var next []int
+		for _, d := range laserMovement[r.Cells()[cell].kind][start.direction] {
+			next = append(next, g.segmentFrom(cell, d))
+		}
+		g.entries[start] = len(g.segments)
+		g.segments = append(g.segments, segment{next: next})
+	}
+	g.condense()
+	g.countTiles()
+	return g
+}
+
+// condense finds the components with Tarjan's algorithm.
+func (g *segmentGraph) condense() {
+	n := len(g.segments)
+	index := make([]int, n)
+	lowlink := make([]int, n)
+	onStack := make([]bool, n)
+	g.component = make([]int, n)
+	for i := range index {
+		index[i] = -1
+	}
+
+	var stack []int
+	next := 0
+	var visit func(v int)
+	visit = func(v int) {
+		index[v] = next
+		lowlink[v] = next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range g.segments[v].next {
+			if index[w] < 0 {
+				visit(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onStack[w] {
+				lowlink[v] = min(lowlink[v], index[w])
+			}
+		}
+
+		if lowlink[v] != index[v] {
+			return
+		}
+
+		id := len(g.components)
+		var members []int
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			g.component[w] = id
+			members = append(members, w)
+			if w == v {
+				break
+			}
+		}
+		g.components = append(g.components, members)
+	}
+
+	for v := range g.segments {
+		if index[v] < 0 {
+			visit(v)
+		}
+	}
+}
+
+// successors are the other components a component leads to.
+func (g *segmentGraph) successors(c int) []int {
+	var succ []int
+	seen := map[int]bool{c: true}
+	for _, m := range g.components[c] {
+		for _, w := range g.segments[m].next {
+			if s := g.component[w]; !seen[s] {
+				seen[s] = true
+				succ = append(succ, s)
+			}
+		}
+	}
+	return succ
+}
+
+// countTiles counts the energized tiles of every component. A bitset only
+// stays cached while a component still to be counted leads to it, and the
+// last one to need it takes it over instead of copying it, which keeps a
+// large room from needing a bitset for every component at once.
+func (g *segmentGraph) countTiles() {
+	words := (len(g.room.Cells()) + 63) / 64
+	succ := make([][]int, len(g.components))
+	uses := make([]int, len(g.components))
+	for c := range g.components {
+		succ[c] = g.successors(c)
+		for _, s := range succ[c] {
+			uses[s]++
+		}
+	}
+
+	cached := make([][]uint64, len(g.components))
+	var free [][]uint64
+	release := func(s int) {
+		uses[s]--
+		if uses[s] == 0 && cached[s] != nil {
+			free = append(free, cached[s])
+			cached[s] = nil
+		}
+	}
+
+	g.counts = make([]int, len(g.components))
+	for c, members := range g.components {
+		var tiles []uint64
+		for _, s := range succ[c] {
+			if tiles == nil && uses[s] == 1 {
+				tiles, cached[s] = cached[s], nil
+			}
+		}
+		if tiles == nil {
+			if len(free) > 0 {
+				tiles = free[len(free)-1]
+				free = free[:len(free)-1]
+				clear(tiles)
+			} else {
+				tiles = make([]uint64, words)
+			}
+		}
+
+		for _, m := range members {
+			for _, t := range g.segments[m].tiles {
+				tiles[t/64] |= 1 << (t % 64)
+			}
+		}
+		for _, s := range succ[c] {
+			if cached[s] != nil {
+				orInto(tiles, cached[s])
+			}
+			release(s)
+		}
+
+		for _, w := range tiles {
+			g.counts[c] += bits.OnesCount64(w)
+		}
+		if uses[c] > 0 {
+			cached[c] = tiles
+		} else {
+			free = append(free, tiles)
+		}
+	}
+}
+
+func orInto(dst, src []uint64) {
+	for i, w := range src {
+		dst[i] |= w
+	}
+}
+
+// energizedCount is how many tiles a laser entering at start lights up.
+// It only reads the graph, so any number of workers can share it.
+func (g *segmentGraph) energizedCount(start Laser) int {
+	return g.counts[g.component[g.entries[start]]]
+}
@@ -0,0 +1,144 @@
+package day17
+
+import (
+	"log"
+	"time"
+
+	"github.com/HallM/aoc2023/grid"
+	"github.com/HallM/aoc2023/search"
+)
+
+var parseNumber = map[rune]int {
+	'0': 0,
+	'1': 1,
+	'2': 2,
+	'3': 3,
+	'4': 4,
+	'5': 5,
+	'6': 6,
+	'7': 7,
+	'8': 8,
+	'9': 9,
+}
+
+const (
+	DIRECTION_NORTH = iota
+	DIRECTION_EAST
+	DIRECTION_SOUTH
+	DIRECTION_WEST
+)
+const (
+	MOVE_LEFT = iota
+	MOVE_RIGHT
+	MOVE_AHEAD
+	MOVE_REVERSE
+)
+
+type Vertex struct {
+	x int
+	y int
+}
+
+var changeDirection = map[int]map[int]int {
+	DIRECTION_NORTH: map[int]int{
+		MOVE_LEFT: DIRECTION_WEST,
+		MOVE_RIGHT: DIRECTION_EAST,
+		MOVE_AHEAD: DIRECTION_NORTH,
+		MOVE_REVERSE: DIRECTION_SOUTH,
+	},
+	DIRECTION_EAST: map[int]int{
+		MOVE_LEFT: DIRECTION_NORTH,
+		MOVE_RIGHT: DIRECTION_SOUTH,
+		MOVE_AHEAD: DIRECTION_EAST,
+		MOVE_REVERSE: DIRECTION_WEST,
+	},
+	DIRECTION_SOUTH: map[int]int{
+		MOVE_LEFT: DIRECTION_EAST,
+		MOVE_RIGHT: DIRECTION_WEST,
+		MOVE_AHEAD: DIRECTION_SOUTH,
+		MOVE_REVERSE: DIRECTION_NORTH,
+	},
+	DIRECTION_WEST: map[int]int{
+		MOVE_LEFT: DIRECTION_SOUTH,
+		MOVE_RIGHT: DIRECTION_NORTH,
+		MOVE_AHEAD: DIRECTION_WEST,
+		MOVE_REVERSE: DIRECTION_EAST,
+	},
+}
+
+var directionOffsets = map[int]Vertex {
+	DIRECTION_NORTH: Vertex{x: 0, y: -1},
+	DIRECTION_EAST: Vertex{x: 1, y: 0},
+	DIRECTION_SOUTH: Vertex{x: 0, y: 1},
+	DIRECTION_WEST: Vertex{x: -1, y: 0},
+}
+
+type Graph struct {
+	*grid.Grid[int]
+}
+
+type Path struct {
+	location Vertex
+	blocksMoved int
+	direction int
+}
+
+func (g *Graph) canMoveTo(loc Vertex) bool {
+	return g.InBounds(loc.x, loc.y)
+}
+
+func (g *Graph) pathToTarget(start, end Vertex, rules MovementRules) (search.Result[Path], bool) {
+	// Facing either way with nothing moved yet lets the first move go east or south.
+	starts := []Path{
+		Path{location: start, direction: DIRECTION_EAST, blocksMoved: 0},
+		Path{location: start, direction: DIRECTION_SOUTH, blocksMoved: 0},
+	}
+
+	startTime := time.Now()
+	defer func() {
+		t := time.Now()
+		d := t.Sub(startTime).Microseconds()
+		if d > 0 {
+			log.Printf("Elapsed %v", d)
+		}
+	}()
+
+	neighbors := func(p Path) []search.Edge[Path] {
+		return p.makeMoves(g, rules)
+	}
+	// Every block costs at least the lightest block, so this never overestimates.
+	minHeat := g.minHeat()
+	heuristic := func(p Path) int {
+		return minHeat * (abs(end.x - p.location.x) + abs(end.y - p.location.y))
+	}
+	// The crucible cannot stop at the end until it has moved far enough to turn.
+	isGoal := func(p Path) bool {
+		return p.location == end && p.blocksMoved >= rules.MinStraight
+	}
+	return search.AStar(starts, neighbors, heuristic, isGoal)
+}
+
+func (g *Graph) minHeat() int {
+	min := 0
+	for i, w := range g.Cells() {
+		if i == 0 || w < min {
+			min = w
+		}
+	}
+	return min
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func parseGraph(contents string) (*Graph, error) {
+	g, err := grid.ParseMap(contents, parseNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &Graph{g}, nil
+}
@@ -0,0 +1,83 @@
+package day17
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/HallM/aoc2023/grid"
+)
+
+var directionArrows = map[int]rune {
+	DIRECTION_NORTH: '^',
+	DIRECTION_EAST: '>',
+	DIRECTION_SOUTH: 'v',
+	DIRECTION_WEST: '<',
+}
+
+// RouteStep is a single block the crucible moves into.
+type RouteStep struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	Direction string `json:"direction"`
+	Heat int `json:"heat"`
+	TotalHeat int `json:"totalHeat"`
+	// how many blocks in a line, including this one, since the last turn
+	Straight int `json:"straight"`
+}
+
+type Route struct {
+	Heat int `json:"heat"`
+	Steps []RouteStep `json:"steps"`
+}
+
+// expandRoute turns the searched states after the start into a step for
+// each block entered, along with the heat lost so far.
+func (g *Graph) expandRoute(states []Path) *Route {
+	route := &Route{}
+	if len(states) == 0 {
+		return route
+	}
+
+	lastDirection := -1
+	straight := 0
+	for _, state := range states[1:] {
+		if state.direction == lastDirection {
+			straight++
+		} else {
+			straight = 1
+		}
+		lastDirection = state.direction
+
+		location := state.location
+		heat := g.Get(location.x, location.y)
+		route.Heat += heat
+		route.Steps = append(route.Steps, RouteStep{
+			X: location.x,
+			Y: location.y,
+			Direction: string(directionArrows[state.direction]),
+			Heat: heat,
+			TotalHeat: route.Heat,
+			Straight: straight,
+		})
+	}
+	return route
+}
+
+// renderRoute draws the route as arrows over the heat loss of each block.
+func (g *Graph) renderRoute(route *Route) string {
+	overlay := grid.New[rune](g.Width, g.Height)
+	for i, w := range g.Cells() {
+		overlay.Cells()[i] = rune('0' + w)
+	}
+	for _, step := range route.Steps {
+		overlay.Set(step.X, step.Y, []rune(step.Direction)[0])
+	}
+	return overlay.Render(func(r rune) rune { return r })
+}
+
+func writeRouteJSON(w io.Writer, route *Route) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(route)
+}
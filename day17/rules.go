@@ -0,0 +1,75 @@
+package day17
+
+import (
+	"fmt"
+
+	"github.com/HallM/aoc2023/search"
+)
+
+// MovementRules limit how far a crucible moves in a straight line.
+type MovementRules struct {
+	// blocks it must move in a line before it can turn or stop
+	MinStraight int
+	// blocks it can move in a line before it must turn
+	MaxStraight int
+	// whether it may turn around and go back the way it came
+	AllowReverse bool
+}
+
+var (
+	crucibleRules      = MovementRules{MinStraight: 1, MaxStraight: 3}
+	ultraCrucibleRules = MovementRules{MinStraight: 4, MaxStraight: 10}
+)
+
+func (r MovementRules) validate() error {
+	if r.MinStraight < 1 {
+		return fmt.Errorf("Crucible must move at least 1 block before turning, got %d", r.MinStraight)
+	}
+	if r.MaxStraight < r.MinStraight {
+		return fmt.Errorf("Crucible max straight %d is less than the min straight %d", r.MaxStraight, r.MinStraight)
+	}
+	return nil
+}
+
+// makeMoves steps a single block at a time. Nothing has been moved yet at
+// the start, which is the only time the crucible can't turn yet still go ahead.
+func (p Path) makeMoves(g *Graph, rules MovementRules) []search.Edge[Path] {
+	var edges []search.Edge[Path]
+
+	moves := make([]int, 0, 4)
+	if p.blocksMoved >= rules.MinStraight {
+		moves = append(moves, MOVE_LEFT)
+		moves = append(moves, MOVE_RIGHT)
+		if rules.AllowReverse {
+			moves = append(moves, MOVE_REVERSE)
+		}
+	}
+	if p.blocksMoved < rules.MaxStraight {
+		moves = append(moves, MOVE_AHEAD)
+	}
+
+	for _, move := range moves {
+		blocksMoved := 1
+		if move == MOVE_AHEAD {
+			blocksMoved = p.blocksMoved + 1
+		}
+
+		newDirection := changeDirection[p.direction][move]
+		offset := directionOffsets[newDirection]
+		coord := Vertex{x: p.location.x + offset.x, y: p.location.y + offset.y}
+		if !g.canMoveTo(coord) {
+			continue
+		}
+
+		edges = append(edges, search.Edge[Path]{
+			To: Path{
+				location:    coord,
+				blocksMoved: blocksMoved,
+				direction:   newDirection,
+			},
+			Cost: g.Get(coord.x, coord.y),
+		})
+	}
+
+	return edges
+}
@@ -0,0 +1,94 @@
+package day17
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+type crucibleSolver struct {
+	rules MovementRules
+
+	render string
+	export string
+	out string
+}
+
+func init() {
+	aoc.Register(17, 1, &crucibleSolver{rules: crucibleRules})
+	aoc.Register(17, 2, &crucibleSolver{rules: ultraCrucibleRules})
+}
+
+func (s *crucibleSolver) RegisterFlags(fs *flag.FlagSet) {
+	fs.IntVar(&s.rules.MinStraight, "min-straight", s.rules.MinStraight, "Blocks the crucible must move in a line before it can turn or stop")
+	fs.IntVar(&s.rules.MaxStraight, "max-straight", s.rules.MaxStraight, "Blocks the crucible can move in a line before it must turn")
+	fs.BoolVar(&s.rules.AllowReverse, "allow-reverse", s.rules.AllowReverse, "Let the crucible turn around")
+	fs.StringVar(&s.render, "render", "", "Draw the route over the map: ascii")
+	fs.StringVar(&s.export, "export", "", "Export every step of the route: json")
+	fs.StringVar(&s.out, "out", "", "File to write the render or export to (default stdout)")
+}
+
+func (s *crucibleSolver) Solve(contents string) (string, error) {
+	if err := s.rules.validate(); err != nil {
+		return "", err
+	}
+	// the render and the export would run together in the one --out
+	if s.render != "" && s.export != "" {
+		return "", errors.New("Only one of --render and --export can be used at a time")
+	}
+
+	str := strings.ReplaceAll(contents, "\r", "")
+
+	graph, err := parseGraph(str)
+	if err != nil {
+		return "", err
+	}
+	result, ok := graph.pathToTarget(Vertex{x: 0, y: 0}, Vertex{x: graph.Width-1, y: graph.Height-1}, s.rules)
+	if !ok {
+		return "", fmt.Errorf("No path found to the bottom right")
+	}
+
+	if s.render != "" || s.export != "" {
+		if err := s.writeRoute(graph, graph.expandRoute(result.Path)); err != nil {
+			return "", err
+		}
+	}
+
+	log.Printf("Heat cost: %d", result.Cost)
+	return strconv.Itoa(result.Cost), nil
+}
+
+func (s *crucibleSolver) writeRoute(graph *Graph, route *Route) error {
+	out, err := aoc.CreateOutput(s.out)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	switch s.render {
+	case "":
+	case "ascii":
+		if _, err := io.WriteString(out, graph.renderRoute(route)+"\n"); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("Unknown render %q, expected ascii", s.render)
+	}
+
+	switch s.export {
+	case "":
+	case "json":
+		if err := writeRouteJSON(out, route); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("Unknown export %q, expected json", s.export)
+	}
+	return nil
+}
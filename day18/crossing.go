@@ -0,0 +1,186 @@
+package day18
+
+import (
+	"cmp"
+	"slices"
+)
+
+// trench is the stretch dug by one instruction, from lo to hi along the axis
+// it runs on, with at being where it sits on the other axis.
+type trench struct {
+	index  int
+	at     int64
+	lo, hi int64
+}
+
+// findCrossing finds a pair of trenches that run into each other, other than
+// neighbours sharing their corner, with the later instruction first. Trenches
+// are axis aligned, so collinear ones are checked by sorting along their line
+// and the rest by sweeping across x with the horizontal trenches active at
+// each x kept in order of y.
+func (plan *DigPlan) findCrossing() (int, int, bool) {
+	var horizontal, vertical []trench
+	for i := range plan.instructions {
+		a, b := plan.vertices[i], plan.vertices[i+1]
+		if a.y == b.y {
+			horizontal = append(horizontal, trench{index: i, at: a.y, lo: min(a.x, b.x), hi: max(a.x, b.x)})
+		} else {
+			vertical = append(vertical, trench{index: i, at: a.x, lo: min(a.y, b.y), hi: max(a.y, b.y)})
+		}
+	}
+
+	if i, j, ok := plan.collinearOverlap(horizontal); ok {
+		return max(i, j), min(i, j), true
+	}
+	if i, j, ok := plan.collinearOverlap(vertical); ok {
+		return max(i, j), min(i, j), true
+	}
+	if i, j, ok := plan.perpendicularCrossing(horizontal, vertical); ok {
+		return max(i, j), min(i, j), true
+	}
+	return 0, 0, false
+}
+
+// neighbours are the instructions dug just before and after i, wrapping
+// around since the loop closes at the origin.
+func (plan *DigPlan) neighbours(i int) []int {
+	n := len(plan.instructions)
+	before, after := (i+n-1)%n, (i+1)%n
+	if before == after {
+		return []int{before}
+	}
+	return []int{before, after}
+}
+
+// adjacent is whether two instructions dig one after the other, counting the
+// last and first since the loop closes at the origin.
+func (plan *DigPlan) adjacent(i, j int) bool {
+	n := len(plan.instructions)
+	return i-j == 1 || j-i == 1 || (min(i, j) == 0 && max(i, j) == n-1)
+}
+
+// collinearOverlap looks for trenches on the same line that overlap. Sorted by
+// where they start, each only needs checking against the one reaching furthest
+// so far, and neighbours may only meet end to end.
+func (plan *DigPlan) collinearOverlap(trenches []trench) (int, int, bool) {
+	slices.SortFunc(trenches, func(a, b trench) int {
+		if a.at != b.at {
+			return cmp.Compare(a.at, b.at)
+		}
+		if a.lo != b.lo {
+			return cmp.Compare(a.lo, b.lo)
+		}
+		return cmp.Compare(a.index, b.index)
+	})
+	var reach trench
+	for k, t := range trenches {
+		if k == 0 || t.at != reach.at || t.lo > reach.hi {
+			reach = t
+			continue
+		}
+		if t.lo < reach.hi || !plan.adjacent(t.index, reach.index) {
+			return t.index, reach.index, true
+		}
+		// they only share a corner, and t reaches further
+		reach = t
+	}
+	return 0, 0, false
+}
+
+// perpendicularCrossing sweeps across x looking for a vertical trench that
+// meets a horizontal one that isn't its neighbour. The active horizontal
+// trenches are counted by y in a Fenwick tree, so a vertical trench can ask
+// how many lie along it in O(log n).
+func (plan *DigPlan) perpendicularCrossing(horizontal, vertical []trench) (int, int, bool) {
+	ys := make([]int64, len(horizontal))
+	for k, h := range horizontal {
+		ys[k] = h.at
+	}
+	slices.Sort(ys)
+	ys = slices.Compact(ys)
+	rank := func(y int64) int {
+		r, _ := slices.BinarySearch(ys, y)
+		return r
+	}
+
+	const (
+		EVENT_START = iota
+		EVENT_VERTICAL
+		EVENT_END
+	)
+	type event struct {
+		x      int64
+		kind   int
+		trench trench
+	}
+	events := make([]event, 0, 2*len(horizontal)+len(vertical))
+	for _, h := range horizontal {
+		events = append(events, event{x: h.lo, kind: EVENT_START, trench: h}, event{x: h.hi, kind: EVENT_END, trench: h})
+	}
+	for _, v := range vertical {
+		events = append(events, event{x: v.at, kind: EVENT_VERTICAL, trench: v})
+	}
+	// Trenches meet at their ends too, so at any x the starts go before the
+	// vertical trenches and the ends after.
+	slices.SortFunc(events, func(a, b event) int {
+		if a.x != b.x {
+			return cmp.Compare(a.x, b.x)
+		}
+		if a.kind != b.kind {
+			return cmp.Compare(a.kind, b.kind)
+		}
+		return cmp.Compare(a.trench.index, b.trench.index)
+	})
+
+	active := make([]int, len(ys)+1)
+	activeByIndex := make(map[int]trench)
+	update := func(r, delta int) {
+		for r++; r < len(active); r += r & -r {
+			active[r] += delta
+		}
+	}
+	below := func(r int) int {
+		total := 0
+		for ; r > 0; r -= r & -r {
+			total += active[r]
+		}
+		return total
+	}
+
+	for _, e := range events {
+		switch e.kind {
+		case EVENT_START:
+			update(rank(e.trench.at), 1)
+			activeByIndex[e.trench.index] = e.trench
+		case EVENT_END:
+			update(rank(e.trench.at), -1)
+			delete(activeByIndex, e.trench.index)
+		case EVENT_VERTICAL:
+			v := e.trench
+			lo, hi := rank(v.lo), rank(v.hi)
+			if hi < len(ys) && ys[hi] == v.hi {
+				hi++
+			}
+			along := below(hi) - below(lo)
+
+			// Only the neighbours can meet it without counting.
+			for _, n := range plan.neighbours(v.index) {
+				if h, ok := activeByIndex[n]; ok && v.lo <= h.at && h.at <= v.hi {
+					along--
+				}
+			}
+			if along == 0 {
+				continue
+			}
+			// There is a crossing, so this search only ever runs once.
+			first := -1
+			for _, h := range activeByIndex {
+				if !plan.adjacent(h.index, v.index) && v.lo <= h.at && h.at <= v.hi && (first < 0 || h.index < first) {
+					first = h.index
+				}
+			}
+			return v.index, first, true
+		}
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,86 @@
+package day18
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+func parsePlan(t *testing.T, lines []string, keep bool) (*DigPlan, error) {
+	t.Helper()
+	var b strings.Builder
+	for _, l := range lines {
+		fmt.Fprintf(&b, "%s (#000000)\n", l)
+	}
+	return parseDigPlan(strings.NewReader(b.String()), ENCODING_DIRECTION, false, keep)
+}
+
+func TestCrossings(t *testing.T) {
+	tests := []struct {
+		name string
+		plan []string
+		// the lines of the reported pair, later first
+		line, other int
+	}{
+		{"perpendicular crossing", []string{"R 4", "D 2", "L 2", "U 3", "L 2", "D 1"}, 4, 1},
+		{"t-touch", []string{"R 4", "D 2", "L 1", "U 2", "U 2", "L 3", "D 2"}, 4, 1},
+		{"collinear overlap", []string{"R 4", "D 1", "L 2", "U 1", "L 2"}, 5, 1},
+		{"retrace", []string{"R 1", "L 1"}, 2, 1},
+		{"loop touching itself at a corner", []string{"R 2", "D 2", "R 2", "D 2", "L 2", "U 2", "L 2", "U 2"}, 7, 3},
+	}
+	for _, tt := range tests {
+		_, err := parsePlan(t, tt.plan, true)
+		var perr *aoc.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("%s: wanted a ParseError, got %v", tt.name, err)
+			continue
+		}
+		want := fmt.Sprintf("a trench that doesn't cross the one dug on line %d", tt.other)
+		if perr.Line != tt.line || perr.Expected != want {
+			t.Errorf("%s: wanted line %d to cross line %d, got %v", tt.name, tt.line, tt.other, perr)
+		}
+	}
+}
+
+func TestValidLoop(t *testing.T) {
+	contents, err := os.ReadFile("part1test.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, encoding := range []Encoding{ENCODING_DIRECTION, ENCODING_HEX} {
+		plan, err := parseDigPlan(strings.NewReader(string(contents)), encoding, false, true)
+		if err != nil {
+			t.Errorf("encoding %d: %v", encoding, err)
+			continue
+		}
+		if i, j, crossed := plan.findCrossing(); crossed {
+			t.Errorf("encoding %d: wanted no crossing, got %d and %d", encoding, i, j)
+		}
+	}
+
+	// neighbours in the same direction only share their corner
+	if _, err := parsePlan(t, []string{"R 2", "R 2", "D 2", "L 4", "U 1", "U 1"}, true); err != nil {
+		t.Errorf("straight runs: %v", err)
+	}
+}
+
+func TestStreamingSkipsCrossings(t *testing.T) {
+	plan, err := parsePlan(t, []string{"R 4", "D 2", "L 2", "U 3", "L 2", "D 1"}, false)
+	if err != nil {
+		t.Fatalf("wanted the crossing to go unchecked, got %v", err)
+	}
+	if len(plan.instructions) != 0 || len(plan.vertices) != 0 {
+		t.Errorf("wanted nothing kept, got %d instructions and %d vertices", len(plan.instructions), len(plan.vertices))
+	}
+	if plan.polygon.count != 6 {
+		t.Errorf("wanted 6 moves measured, got %d", plan.polygon.count)
+	}
+
+	if _, err := parsePlan(t, []string{"R 4", "D 2"}, false); err == nil {
+		t.Errorf("wanted an unclosed plan to still fail")
+	}
+}
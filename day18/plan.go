@@ -0,0 +1,166 @@
+package day18
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+// Encoding is which part of a dig plan line says where to dig.
+type Encoding int
+
+const (
+	// ENCODING_DIRECTION reads the letter and distance, like "R 6".
+	ENCODING_DIRECTION Encoding = iota
+	// ENCODING_HEX reads the distance from the first five hex digits of the
+	// color and the direction from the last.
+	ENCODING_HEX
+)
+
+func parseEncoding(name string) (Encoding, error) {
+	switch name {
+	case "direction":
+		return ENCODING_DIRECTION, nil
+	case "hex":
+		return ENCODING_HEX, nil
+	}
+	return 0, fmt.Errorf("Unknown encoding %q, expected direction or hex", name)
+}
+
+var directionOffsets = map[byte]Vertex{
+	'U': {x: 0, y: -1},
+	'D': {x: 0, y: 1},
+	'L': {x: -1, y: 0},
+	'R': {x: 1, y: 0},
+}
+
+// hexDirections are the directions by the last hex digit of the color.
+const hexDirections = "RDLU"
+
+// Instruction is one line of the dig plan.
+type Instruction struct {
+	direction byte
+	distance  int64
+	color     color.RGBA
+	// line is only the line number, to keep a long plan small
+	line int
+}
+
+// DigPlan measures the trench as each instruction is read. Checking for
+// crossings and rendering need the whole plan, so only then does it keep
+// every instruction in order, along with the corners of the trench they dig
+// out, starting and ending at the origin.
+type DigPlan struct {
+	polygon *Polygon
+	// end is where the trench has got to so far
+	end Vertex
+
+	keep         bool
+	instructions []Instruction
+	vertices     []Vertex
+}
+
+func parseInstruction(line aoc.SourceLine, encoding Encoding) (Instruction, error) {
+	fields := line.Whole().Fields()
+	if len(fields) != 3 {
+		return Instruction{}, line.Errorf(line.Whole(), "a direction, a distance and a color like %q", "R 6 (#70c710)")
+	}
+
+	inst := Instruction{line: line.Number}
+	inst.direction = fields[0].Text[0]
+	if _, ok := directionOffsets[inst.direction]; !ok || len(fields[0].Text) != 1 {
+		return Instruction{}, line.Errorf(fields[0], "one of %q", "UDLR")
+	}
+	distance, err := line.Int(fields[1], 10, 32)
+	if err != nil {
+		return Instruction{}, err
+	}
+	inst.distance = distance
+	distanceField := fields[1]
+
+	text := fields[2].Text
+	if !strings.HasPrefix(text, "(#") || !strings.HasSuffix(text, ")") || len(text) != len("(#70c710)") {
+		return Instruction{}, line.Errorf(fields[2], "a color like %q", "(#70c710)")
+	}
+	hex := fields[2].Slice(2, len(text)-1)
+	if strings.Trim(hex.Text, "0123456789abcdefABCDEF") != "" {
+		return Instruction{}, line.Errorf(hex, "6 hex digits")
+	}
+	rgb, err := line.Int(hex, 16, 32)
+	if err != nil {
+		return Instruction{}, err
+	}
+	inst.color = color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 255}
+
+	if encoding == ENCODING_HEX {
+		last := hex.From(5)
+		d := int(rgb & 0xf)
+		if d >= len(hexDirections) {
+			return Instruction{}, line.Errorf(last, "a direction from 0 to 3")
+		}
+		inst.direction = hexDirections[d]
+		inst.distance = rgb >> 4
+		distanceField = hex.Slice(0, 5)
+	}
+
+	if inst.distance <= 0 {
+		return Instruction{}, line.Errorf(distanceField, "a distance of at least 1")
+	}
+	return inst, nil
+}
+
+func parseDigPlan(contents io.Reader, encoding Encoding, bigint, keep bool) (*DigPlan, error) {
+	plan := &DigPlan{polygon: newPolygon(bigint), keep: keep}
+	if keep {
+		plan.vertices = []Vertex{{x: 0, y: 0}}
+	}
+	var last aoc.SourceLine
+
+	err := aoc.EachLine(contents, func(text string, n int) error {
+		last = aoc.SourceLine{Number: n, Text: text}
+		inst, err := parseInstruction(last, encoding)
+		if err != nil {
+			return err
+		}
+		plan.add(inst)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if plan.polygon.count == 0 {
+		return plan, nil
+	}
+	if end := plan.end; end != (Vertex{}) {
+		return nil, last.Errorf(last.End(), "the plan to end back at the start, not at (%d, %d)", end.x, end.y)
+	}
+	if !keep {
+		return plan, nil
+	}
+	plan.polygon.vertices = plan.vertices
+	if i, j, crossed := plan.findCrossing(); crossed {
+		inst := plan.instructions[i]
+		return nil, &aoc.ParseError{
+			Line:     inst.line,
+			Expected: fmt.Sprintf("a trench that doesn't cross the one dug on line %d", plan.instructions[j].line),
+			Got:      fmt.Sprintf("%c %d", inst.direction, inst.distance),
+		}
+	}
+	return plan, nil
+}
+
+func (plan *DigPlan) add(inst Instruction) {
+	offset := directionOffsets[inst.direction]
+	plan.end = Vertex{x: plan.end.x + offset.x*inst.distance, y: plan.end.y + offset.y*inst.distance}
+	plan.polygon.move(offset, inst.distance)
+	log.Printf("next up [%d, %d]", plan.polygon.last.x, plan.polygon.last.y)
+	if plan.keep {
+		plan.instructions = append(plan.instructions, inst)
+		plan.vertices = append(plan.vertices, plan.end)
+	}
+}
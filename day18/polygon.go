@@ -0,0 +1,164 @@
+package day18
+
+import (
+	"log"
+	"math/big"
+
+	"github.com/HallM/aoc2023/checked"
+)
+
+type Vertex struct {
+	x int64
+	y int64
+}
+
+// Polygon starts at the origin and keeps a running tally as each move is
+// made. The vertices are only filled in when the dig plan keeps them.
+//
+// The trench is on the lattice, so the shoelace formula gives the area of
+// the polygon through the middle of every trench block, and Pick's theorem
+// (area = interior + boundary/2 - 1) turns that into whole blocks.
+type Polygon struct {
+	vertices []Vertex
+	last Vertex
+	count int
+	// twice the signed area so far, by the shoelace formula
+	shoelace int64
+	// how many blocks of trench have been dug so far
+	boundary int64
+	// err is the first overflow, after which the int64 tally stops.
+	err error
+
+	// exact is the same tally but with arbitrary precision, only kept in bigint mode.
+	exact *bigTally
+}
+
+type bigTally struct {
+	x *big.Int
+	y *big.Int
+	shoelace *big.Int
+	boundary *big.Int
+}
+
+func newPolygon(bigint bool) *Polygon {
+	p := &Polygon{}
+	if bigint {
+		p.exact = &bigTally{x: new(big.Int), y: new(big.Int), shoelace: new(big.Int), boundary: new(big.Int)}
+	}
+	return p
+}
+
+// move digs distance blocks in the direction of offset.
+func (p *Polygon) move(offset Vertex, distance int64) {
+	if p.exact != nil {
+		p.exact.move(offset, distance)
+	}
+	if p.err == nil {
+		p.err = p.tally(offset, distance)
+	}
+	p.count++
+}
+
+func (p *Polygon) tally(offset Vertex, distance int64) error {
+	// offsets are a single step along one axis, so these can't overflow
+	dx, dy := offset.x * distance, offset.y * distance
+	x, err := checked.Add(p.last.x, dx)
+	if err != nil {
+		return err
+	}
+	y, err := checked.Add(p.last.y, dy)
+	if err != nil {
+		return err
+	}
+	next := Vertex{x: x, y: y}
+
+	cross, err := crossProduct(p.last, next)
+	if err != nil {
+		return err
+	}
+	if p.shoelace, err = checked.Add(p.shoelace, cross); err != nil {
+		return err
+	}
+	if p.boundary, err = checked.Add(p.boundary, distance); err != nil {
+		return err
+	}
+	p.last = next
+	return nil
+}
+
+// crossProduct is a.x*b.y - a.y*b.x, one term of the shoelace formula.
+func crossProduct(a, b Vertex) (int64, error) {
+	xy, err := checked.Mul(a.x, b.y)
+	if err != nil {
+		return 0, err
+	}
+	yx, err := checked.Mul(a.y, b.x)
+	if err != nil {
+		return 0, err
+	}
+	return checked.Sub(xy, yx)
+}
+
+func (t *bigTally) move(offset Vertex, distance int64) {
+	x := new(big.Int).Add(t.x, big.NewInt(offset.x * distance))
+	y := new(big.Int).Add(t.y, big.NewInt(offset.y * distance))
+	cross := new(big.Int).Mul(t.x, y)
+	cross.Sub(cross, new(big.Int).Mul(t.y, x))
+	t.shoelace.Add(t.shoelace, cross)
+	t.boundary.Add(t.boundary, big.NewInt(distance))
+	t.x, t.y = x, y
+}
+
+// counts splits the dug out blocks into those inside the trench and the trench itself.
+func (p *Polygon) counts() (interior int64, boundary int64, err error) {
+	if p.count < 3 {
+		log.Printf("not enough verts %d", p.count)
+		return 0, 0, nil
+	}
+	if p.err != nil {
+		return 0, 0, p.err
+	}
+
+	// The plan should end back at the origin, so closing it adds nothing to
+	// the shoelace, only the distance back to it.
+	boundary = p.boundary
+	for _, d := range []int64{p.last.x, p.last.y} {
+		if d < 0 {
+			d = -d
+		}
+		if boundary, err = checked.Add(boundary, d); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	twiceArea := p.shoelace
+	if twiceArea < 0 {
+		twiceArea = -twiceArea
+	}
+	// Pick's theorem doubled: 2*interior = 2*area - boundary + 2
+	twiceInterior, err := checked.Sub(twiceArea, boundary)
+	if err != nil {
+		return 0, 0, err
+	}
+	if twiceInterior, err = checked.Add(twiceInterior, 2); err != nil {
+		return 0, 0, err
+	}
+	return twiceInterior / 2, boundary, nil
+}
+
+// bigCounts is counts for bigint mode.
+func (p *Polygon) bigCounts() (interior *big.Int, boundary *big.Int) {
+	if p.count < 3 {
+		log.Printf("not enough verts %d", p.count)
+		return new(big.Int), new(big.Int)
+	}
+	t := p.exact
+	boundary = new(big.Int).Add(t.boundary, new(big.Int).Abs(t.x))
+	boundary.Add(boundary, new(big.Int).Abs(t.y))
+
+	interior = new(big.Int).Abs(t.shoelace)
+	interior.Sub(interior, boundary)
+	interior.Add(interior, big.NewInt(2))
+	interior.Quo(interior, big.NewInt(2))
+	return interior, boundary
+}
@@ -0,0 +1,66 @@
+package day18
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+)
+
+// svgSize is how many pixels the longest side of the lagoon is drawn across.
+const svgSize = 800
+
+const svgMargin = 20
+
+// svgCaption is the height of the band under the lagoon for the area.
+const svgCaption = 30
+
+var lagoonFill = color.RGBA{R: 0x4f, G: 0x8f, B: 0xc8, A: 255}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// writeSVG draws the trench with every edge in the color from its
+// instruction, scaled down to fit however far the plan digs.
+func writeSVG(w io.Writer, plan *DigPlan, polygon *Polygon, counts lagoonCounts) error {
+	lo, hi := polygon.vertices[0], polygon.vertices[0]
+	for _, v := range polygon.vertices {
+		lo = Vertex{x: min(lo.x, v.x), y: min(lo.y, v.y)}
+		hi = Vertex{x: max(hi.x, v.x), y: max(hi.y, v.y)}
+	}
+	// Each vertex is the middle of a block, so the lagoon is a block wider than the span.
+	span := max(hi.x-lo.x, hi.y-lo.y) + 1
+	scale := float64(svgSize) / float64(span)
+	point := func(v Vertex) (float64, float64) {
+		return svgMargin + (float64(v.x-lo.x)+0.5)*scale, svgMargin + (float64(v.y-lo.y)+0.5)*scale
+	}
+	width := svgMargin*2 + float64(hi.x-lo.x+1)*scale
+	height := svgMargin*2 + float64(hi.y-lo.y+1)*scale + svgCaption
+	stroke := min(max(scale, 2), 8)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 %.0f %.0f\" width=\"%.0f\" height=\"%.0f\">\n", width, height, width, height)
+	fmt.Fprintf(&b, "  <rect width=\"100%%\" height=\"100%%\" fill=\"#ffffff\"/>\n")
+
+	var points []string
+	for _, v := range polygon.vertices {
+		x, y := point(v)
+		points = append(points, fmt.Sprintf("%.2f,%.2f", x, y))
+	}
+	fmt.Fprintf(&b, "  <polygon points=\"%s\" fill=\"%s\" fill-opacity=\"0.5\"/>\n", strings.Join(points, " "), hexColor(lagoonFill))
+
+	for i, inst := range plan.instructions {
+		x1, y1 := point(polygon.vertices[i])
+		x2, y2 := point(polygon.vertices[i+1])
+		fmt.Fprintf(&b, "  <line x1=\"%.2f\" y1=\"%.2f\" x2=\"%.2f\" y2=\"%.2f\" stroke=\"%s\" stroke-width=\"%.2f\" stroke-linecap=\"square\"/>\n",
+			x1, y1, x2, y2, hexColor(inst.color), stroke)
+	}
+
+	fmt.Fprintf(&b, "  <text x=\"%d\" y=\"%.0f\" font-family=\"sans-serif\" font-size=\"16\">Area: %s (interior %s, boundary %s)</text>\n",
+		svgMargin, height-svgCaption/2, counts.area, counts.interior, counts.boundary)
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,121 @@
+package day18
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+	"github.com/HallM/aoc2023/checked"
+)
+
+type lagoonSolver struct {
+	encoding string
+	bigint bool
+	interiorOnly bool
+	boundaryOnly bool
+	render string
+	out string
+	checkCrossings bool
+}
+
+func init() {
+	aoc.Register(18, 1, &lagoonSolver{encoding: "direction"})
+	aoc.Register(18, 2, &lagoonSolver{encoding: "hex"})
+}
+
+func (s *lagoonSolver) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&s.encoding, "encoding", s.encoding, "Which part of each line says where to dig: direction or hex")
+	fs.BoolVar(&s.bigint, "bigint", false, "Use arbitrary precision instead of failing when the area overflows an int64")
+	fs.BoolVar(&s.interiorOnly, "interior-only", false, "Only count the blocks inside the trench")
+	fs.BoolVar(&s.boundaryOnly, "boundary-only", false, "Only count the blocks of the trench itself")
+	fs.StringVar(&s.render, "render", "", "Draw the lagoon: svg")
+	fs.StringVar(&s.out, "out", "", "File to write the render to (default stdout)")
+	fs.BoolVar(&s.checkCrossings, "check-crossings", true, "Check the trench never crosses itself, which keeps the whole plan in memory")
+}
+
+func (s *lagoonSolver) Solve(contents string) (string, error) {
+	return s.SolveStream(strings.NewReader(contents))
+}
+
+// lagoonCounts are the answers already formatted, since they may be big ints.
+type lagoonCounts struct {
+	interior string
+	boundary string
+	area string
+}
+
+func (s *lagoonSolver) measure(polygon *Polygon) (lagoonCounts, error) {
+	if s.bigint {
+		interior, boundary := polygon.bigCounts()
+		area := new(big.Int).Add(interior, boundary)
+		return lagoonCounts{interior: interior.String(), boundary: boundary.String(), area: area.String()}, nil
+	}
+
+	interior, boundary, err := polygon.counts()
+	if err != nil {
+		return lagoonCounts{}, fmt.Errorf("%w, try --bigint", err)
+	}
+	// a lagoon within a hair of the int64 limit may still not fit once both are added
+	area, err := checked.Add(interior, boundary)
+	if err != nil {
+		return lagoonCounts{}, fmt.Errorf("%w, try --bigint", err)
+	}
+	return lagoonCounts{
+		interior: strconv.FormatInt(interior, 10),
+		boundary: strconv.FormatInt(boundary, 10),
+		area: strconv.FormatInt(area, 10),
+	}, nil
+}
+
+func (s *lagoonSolver) SolveStream(contents io.Reader) (string, error) {
+	if s.interiorOnly && s.boundaryOnly {
+		return "", errors.New("Only one of --interior-only and --boundary-only can be used")
+	}
+	if s.render != "" && s.render != "svg" {
+		return "", fmt.Errorf("Unknown render %q, expected svg", s.render)
+	}
+
+	encoding, err := parseEncoding(s.encoding)
+	if err != nil {
+		return "", err
+	}
+	// Without a check or a render, nothing but the running tally is kept.
+	keep := s.checkCrossings || s.render != ""
+	plan, err := parseDigPlan(contents, encoding, s.bigint, keep)
+	if err != nil {
+		return "", err
+	}
+	polygon := plan.polygon
+
+	counts, err := s.measure(polygon)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Interior: %s, boundary: %s", counts.interior, counts.boundary)
+	log.Printf("Area: %s", counts.area)
+
+	if s.render == "svg" {
+		out, err := aoc.CreateOutput(s.out)
+		if err != nil {
+			return "", err
+		}
+		defer out.Close()
+		if err := writeSVG(out, plan, polygon, counts); err != nil {
+			return "", err
+		}
+	}
+
+	switch {
+	case s.interiorOnly:
+		return counts.interior, nil
+	case s.boundaryOnly:
+		return counts.boundary, nil
+	}
+	return counts.area, nil
+}
@@ -0,0 +1,80 @@
+package day2
+
+import (
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+type Game struct {
+	ID int
+	Max Set
+}
+
+type Set struct {
+	Red int
+	Green int
+	Blue int
+}
+
+func parseGame(line aoc.SourceLine) (*Game, error) {
+	if len(strings.TrimSpace(line.Text)) == 0 {
+		return nil, nil
+	}
+
+	header, sets, found := line.Whole().Cut(":")
+	if !found {
+		return nil, line.Errorf(line.End(), "%q after the game ID", ":")
+	}
+	if !strings.HasPrefix(header.Text, "Game ") {
+		return nil, line.Errorf(header, "%q", "Game <ID>")
+	}
+
+	// Skip "Game " and up to the ':' is the ID.
+	id, err := line.Int(header.From(5), 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	game := &Game{ID: int(id)}
+
+	for _, set := range sets.Split(";") {
+		// Just in case a set could possibly have a color listed twice,
+		// I will sum up the colors first.
+		var r, g, b int
+
+		for _, part := range set.Split(",") {
+			part = part.TrimSpace()
+			count, color, found := part.Cut(" ")
+			if !found {
+				return nil, line.Errorf(part, "a count then a color")
+			}
+			x, err := line.Int(count, 10, 32)
+			if err != nil {
+				return nil, err
+			}
+			v := int(x)
+
+			if color.Text == "red" {
+				r += v
+			} else if color.Text == "green" {
+				g += v
+			} else if color.Text == "blue" {
+				b += v
+			} else {
+				return nil, line.Errorf(color, "red, green or blue")
+			}
+		}
+
+		if r > game.Max.Red {
+			game.Max.Red = r
+		}
+		if g > game.Max.Green {
+			game.Max.Green = g
+		}
+		if b > game.Max.Blue {
+			game.Max.Blue = b
+		}
+	}
+	return game, nil
+}
@@ -0,0 +1,127 @@
+package day3
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+type Point struct {
+	X int
+	Y int
+}
+
+type Rect struct {
+	// Left is minimum X
+	Left int
+	Right int
+
+	// Top is minimum Y
+	Top int
+	Bottom int
+}
+
+func (r *Rect) containsPoint(point *Point) bool {
+	return point.X >= r.Left && point.X <= r.Right && point.Y >= r.Top && point.Y <= r.Bottom
+}
+
+type EnginePart struct {
+	ID int
+	Collider *Rect
+}
+
+func (p *EnginePart) print() {
+	log.Printf("Part %d: (%d, %d, %d, %d)", p.ID, p.Collider.Left, p.Collider.Right, p.Collider.Top, p.Collider.Bottom)
+}
+
+type Symbol struct {
+	Char rune
+	Location *Point
+}
+
+func (p *Symbol) print() {
+	log.Printf("Symbol %q: (%d, %d)", p.Char, p.Location.X, p.Location.Y)
+}
+
+type Schematic struct {
+	Parts []*EnginePart
+	Symbols []*Symbol
+}
+
+func parseEngine(contents string) (*Schematic, error) {
+	lines := aoc.SourceLines(contents, 1)
+	if len(lines) > 1 {
+		// All lines must be the same length
+		want := len(strings.TrimSpace(lines[0].Text))
+		for _, line := range lines {
+			l := strings.TrimSpace(line.Text)
+			if len(l) == 0 {
+				continue
+			}
+			if len(l) != want {
+				return nil, &aoc.ParseError{
+					Line: line.Number,
+					Expected: fmt.Sprintf("a line %d wide like the first, not %d", want, len(l)),
+					Got: line.Text,
+					Source: line.Text,
+				}
+			}
+		}
+	}
+
+	schematic := &Schematic{}
+
+	for y, source := range lines {
+		start := -1
+		field := source.Whole().TrimSpace()
+		line := field.Text
+		if len(line) == 0 {
+			continue
+		}
+
+		for x, char := range line {
+			if unicode.IsDigit(char) {
+				if start == -1 {
+					start = x
+				}
+			} else {
+				if start != -1 {
+					id, err := source.Int(field.Slice(start, x), 10, 32)
+					if err != nil {
+						return nil, err
+					}
+					schematic.Parts = append(schematic.Parts, &EnginePart{
+						ID: int(id),
+						// Collider is 1 larger than the number since the symbol can be nearby
+						Collider: &Rect{Left: start-1, Right: x, Top: y-1, Bottom: y+1},
+					})
+				}
+				start = -1
+
+				if char != '.' {
+					schematic.Symbols = append(schematic.Symbols, &Symbol{
+						Char: char,
+						Location: &Point{X: x, Y: y},
+					})
+				}
+			}
+		}
+
+		if start != -1 {
+			id, err := source.Int(field.From(start), 10, 32)
+			if err != nil {
+				return nil, err
+			}
+			schematic.Parts = append(schematic.Parts, &EnginePart{
+				ID: int(id),
+				// Collider is 1 larger than the number since the symbol can be nearby
+				Collider: &Rect{Left: start-1, Right: len(line), Top: y-1, Bottom: y+1},
+			})
+		}
+	}
+
+	return schematic, nil
+}
@@ -0,0 +1,62 @@
+package day4
+
+import (
+	"log"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+type Card struct {
+	ID int64
+	winningNumbers map[int]bool
+	numbers []int
+}
+
+func (c *Card) matches() []int {
+	var matched []int
+	for _, n := range c.numbers {
+		if c.winningNumbers[n] {
+			matched = append(matched, n)
+		}
+	}
+	return matched
+}
+
+func parseCard(line aoc.SourceLine) (*Card, error) {
+	header, rest, found := line.Whole().Cut(":")
+	if !found {
+		return nil, line.Errorf(line.End(), "%q after the card ID", ":")
+	}
+	if !strings.HasPrefix(header.Text, "Card") {
+		return nil, line.Errorf(header, "%q", "Card <ID>")
+	}
+	id, err := line.Int(header.From(4).TrimSpace(), 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	winning, have, found := rest.Cut("|")
+	if !found {
+		return nil, line.Errorf(line.End(), "%q between the winning numbers and the numbers you have", "|")
+	}
+
+	card := &Card{ID: id, winningNumbers: map[int]bool{}}
+	for _, s := range winning.Fields() {
+		val, err := line.Int(s, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		card.winningNumbers[int(val)] = true
+	}
+	log.Printf("All winning numbers %v", card.winningNumbers)
+
+	for _, s := range have.Fields() {
+		val, err := line.Int(s, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		card.numbers = append(card.numbers, int(val))
+	}
+	return card, nil
+}
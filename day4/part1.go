@@ -1,97 +1,53 @@
-package main
+package day4
 
 import (
-	"flag"
-	"fmt"
+	"io"
 	"log"
-	"os"
 	"strings"
 	"strconv"
-)
-
-var filePath = flag.String("file", "part1test.txt", "File path to the scratch offs file")
 
-func computeSum(contents string) (int, error) {
-	lines := strings.Split(contents, "\n")
+	"github.com/HallM/aoc2023/aoc"
+)
 
+func computeSum(contents io.Reader) (int, error) {
 	var total int
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if len(line) == 0 {
-			continue
+	err := aoc.EachLine(contents, func(line string, n int) error {
+		if len(strings.TrimSpace(line)) == 0 {
+			return nil
 		}
 
-		score, err := computeScratchoffScore(line)
+		card, err := parseCard(aoc.SourceLine{Number: n, Text: line})
 		if err != nil {
-			return 0, err
+			return err
 		}
-		total += score
-	}
-	return total, nil
+		total += computeScratchoffScore(card)
+		return nil
+	})
+	return total, err
 }
 
-func computeScratchoffScore(contents string) (int, error) {
-	// Skip over the "Card #:"
-	segments := strings.Split(contents[strings.Index(contents, ":")+1:], "|")
-	if len(segments) != 2 {
-		return 0, fmt.Errorf("Unknown format for %q", contents)
-	}
-
-	winningNumbers := map[int]bool{}
-	for _, s := range strings.Split(strings.TrimSpace(segments[0]), " ") {
-		// Note that splitting like "52  1" will create 52,"",1
-		if len(s) == 0 || s == " " {
-			continue
-		}
-
-		val, err := strconv.ParseInt(s, 10, 32)
-		if err != nil {
-			return 0, fmt.Errorf("Cannot parse number from %q as int: %w", s, err)
-		}
-
-		winningNumbers[int(val)] = true
-	}
-	log.Printf("All winning numbers %v", winningNumbers)
-
+func computeScratchoffScore(card *Card) int {
 	score := 0
-	for _, s := range strings.Split(strings.TrimSpace(segments[1]), " ") {
-		if len(s) == 0 || s == " " {
-			continue
-		}
-
-		val, err := strconv.ParseInt(s, 10, 32)
-		if err != nil {
-			return 0, fmt.Errorf("Cannot parse number from %q as int: %w", s, err)
-		}
-
-		if winningNumbers[int(val)] {
-			log.Printf("Have matching number %d", val)
-			if score == 0 {
-				score = 1
-			} else {
-				score *= 2
-			}
+	for _, val := range card.matches() {
+		log.Printf("Have matching number %d", val)
+		if score == 0 {
+			score = 1
+		} else {
+			score *= 2
 		}
 	}
-
-	return score, nil
+	return score
 }
 
-func main() {
-	flag.Parse()
-
-	if *filePath == "" {
-		log.Fatalf("Must specify the scratch off file!")
-	}
-
-	contents, err := os.ReadFile(*filePath)
-	if err != nil {
-		log.Fatal(err)
-	}
+func init() {
+	aoc.Register(4, 1, aoc.StreamFunc(solvePart1))
+}
 
-	score, err := computeSum(string(contents))
+func solvePart1(contents io.Reader) (string, error) {
+	score, err := computeSum(contents)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
 	}
 	log.Printf("Total: %d", score)
+	return strconv.Itoa(score), nil
 }
@@ -1,42 +1,38 @@
-package main
+package day4
 
 import (
-	"flag"
-	"fmt"
+	"io"
 	"log"
-	"os"
 	"strings"
 	"strconv"
-)
-
-var filePath = flag.String("file", "part1test.txt", "File path to the scratch offs file")
 
-func computeSum(contents string) (int, error) {
-	lines := strings.Split(contents, "\n")
+	"github.com/HallM/aoc2023/aoc"
+)
 
+func computeCopiesSum(contents io.Reader) (int, error) {
 	scratch := &Scratchoffs{copies: map[int64]int{}}
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if len(line) == 0 {
-			continue
+
+	// Only earlier cards win copies of a card, so its count is final once it
+	// is scored. Copies of cards past the last one are never counted.
+	total := 0
+	err := aoc.EachLine(contents, func(line string, n int) error {
+		if len(strings.TrimSpace(line)) == 0 {
+			return nil
 		}
 
-		err := scratch.computeScratchoffCopies(line)
+		card, err := parseCard(aoc.SourceLine{Number: n, Text: line})
 		if err != nil {
-			return 0, err
+			return err
 		}
-	}
+		scratch.computeScratchoffCopies(card)
 
-	total := 0
-	for ID, copies := range scratch.copies {
-		// Do not count copies of cards we do not have
-		if ID > scratch.maxID {
-			break
-		}
-		log.Printf("Card %d has %d copies", ID, copies)
+		copies := scratch.copies[scratch.maxID]
+		log.Printf("Card %d has %d copies", scratch.maxID, copies)
 		total += copies
-	}
-	return total, nil
+		delete(scratch.copies, scratch.maxID)
+		return nil
+	})
+	return total, err
 }
 
 type Scratchoffs struct {
@@ -44,73 +40,27 @@ type Scratchoffs struct {
 	maxID int64
 }
 
-func (scratch *Scratchoffs) computeScratchoffCopies(contents string) error {
-	index := strings.Index(contents, ":")
-	cardID, err := strconv.ParseInt(strings.TrimSpace(contents[5:index]), 10, 32)
-	if err != nil {
-		return fmt.Errorf("Cannot parse number from %q as int: %w", strings.TrimSpace(contents[5:index]), err)
-	}
-
-	scratch.maxID = cardID
-	scratch.copies[cardID]++
-
-	segments := strings.Split(contents[index+1:], "|")
-	if len(segments) != 2 {
-		return fmt.Errorf("Unknown format for %q", contents)
-	}
-
-	winningNumbers := map[int]bool{}
-	for _, s := range strings.Split(strings.TrimSpace(segments[0]), " ") {
-		// Note that splitting like "52  1" will create 52,"",1
-		if len(s) == 0 || s == " " {
-			continue
-		}
-
-		val, err := strconv.ParseInt(s, 10, 32)
-		if err != nil {
-			return fmt.Errorf("Cannot parse number from %q as int: %w", s, err)
-		}
-
-		winningNumbers[int(val)] = true
-	}
-	log.Printf("All winning numbers %v", winningNumbers)
+func (scratch *Scratchoffs) computeScratchoffCopies(card *Card) {
+	scratch.maxID = card.ID
+	scratch.copies[card.ID]++
 
 	next := int64(1)
-	for _, s := range strings.Split(strings.TrimSpace(segments[1]), " ") {
-		if len(s) == 0 || s == " " {
-			continue
-		}
-
-		val, err := strconv.ParseInt(s, 10, 32)
-		if err != nil {
-			return fmt.Errorf("Cannot parse number from %q as int: %w", s, err)
-		}
-
-		if winningNumbers[int(val)] {
-			log.Printf("Have matching number %d, adding a copy of %d", val, cardID + next)
-			scratch.copies[cardID + next] += scratch.copies[cardID]
-			next++
-		}
+	for _, val := range card.matches() {
+		log.Printf("Have matching number %d, adding a copy of %d", val, card.ID + next)
+		scratch.copies[card.ID + next] += scratch.copies[card.ID]
+		next++
 	}
-
-	return nil
 }
 
-func main() {
-	flag.Parse()
-
-	if *filePath == "" {
-		log.Fatalf("Must specify the scratch off file!")
-	}
-
-	contents, err := os.ReadFile(*filePath)
-	if err != nil {
-		log.Fatal(err)
-	}
+func init() {
+	aoc.Register(4, 2, aoc.StreamFunc(solvePart2))
+}
 
-	score, err := computeSum(string(contents))
+func solvePart2(contents io.Reader) (string, error) {
+	score, err := computeCopiesSum(contents)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
 	}
 	log.Printf("Total: %d", score)
+	return strconv.Itoa(score), nil
 }
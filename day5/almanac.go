@@ -0,0 +1,102 @@
+package day5
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+	"github.com/HallM/aoc2023/interval"
+)
+
+type Almanac struct {
+	seeds aoc.SourceLine
+	maps []*interval.IntervalMap[int64]
+}
+
+// parseAlmanac leaves the seeds line for each part to read its own way.
+func parseAlmanac(contents string) (*Almanac, error) {
+	lines := aoc.SourceLines(contents, 1)
+	if !strings.HasPrefix(lines[0].Text, "seeds:") {
+		return nil, lines[0].Errorf(lines[0].Whole(), "%q", "seeds: <numbers>")
+	}
+	almanac := &Almanac{seeds: lines[0]}
+
+	var block []aoc.SourceLine
+	addMap := func() error {
+		if len(block) == 0 {
+			return nil
+		}
+		header := block[0]
+		if !strings.HasSuffix(header.Text, "map:") {
+			return header.Errorf(header.Whole(), "%q", "<from>-to-<to> map:")
+		}
+		rm, err := parseRangemap(block[1:])
+		if err != nil {
+			return err
+		}
+		almanac.maps = append(almanac.maps, rm)
+		block = nil
+		return nil
+	}
+
+	for _, line := range lines[1:] {
+		if len(strings.TrimSpace(line.Text)) == 0 {
+			if err := addMap(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	if err := addMap(); err != nil {
+		return nil, err
+	}
+	return almanac, nil
+}
+
+func parseRangemap(lines []aoc.SourceLine) (*interval.IntervalMap[int64], error) {
+	var mappings []interval.Mapping[int64]
+
+	for _, line := range lines {
+		parts := line.Whole().Fields()
+		if len(parts) != 3 {
+			return nil, line.Errorf(line.Whole(), "3 numbers (dest, src, size), not %d", len(parts))
+		}
+
+		var values [3]int64
+		for i, part := range parts {
+			v, err := line.Int(part, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+
+		dest, src, size := values[0], values[1], values[2]
+		if size < 0 {
+			return nil, line.Errorf(parts[2], "a size of at least 0")
+		}
+		mappings = append(mappings, interval.Mapping[int64]{
+			Interval: interval.Interval[int64]{Start: src, End: src + size},
+			Offset: dest - src,
+		})
+	}
+
+	m, err := interval.NewIntervalMap(mappings...)
+	var overlap *interval.OverlapError[int64]
+	if errors.As(err, &overlap) {
+		line := lines[overlap.Second]
+		return nil, line.Errorf(line.Whole(), "a range that doesn't overlap line %d", lines[overlap.First].Number)
+	}
+	return m, err
+}
+
+// seedToLocation composes every map in the almanac into one, so a seed
+// goes straight to its location without walking the chain.
+func (a *Almanac) seedToLocation() *interval.IntervalMap[int64] {
+	composed, _ := interval.NewIntervalMap[int64]()
+	for _, m := range a.maps {
+		composed = composed.Compose(m)
+	}
+	return composed
+}
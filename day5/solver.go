@@ -0,0 +1,106 @@
+package day5
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+
+	"github.com/HallM/aoc2023/aoc"
+	"github.com/HallM/aoc2023/interval"
+)
+
+type almanacSolver struct {
+	parseSeeds func(line aoc.SourceLine) (interval.Set[int64], error)
+	seedFor    string
+	dump       bool
+	out        string
+}
+
+func init() {
+	aoc.Register(5, 1, &almanacSolver{parseSeeds: parseSeeds})
+	aoc.Register(5, 2, &almanacSolver{parseSeeds: parseSeedRanges})
+}
+
+func (s *almanacSolver) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&s.seedFor, "seed-for", "", "Answer with the lowest seed that ends up at this location instead")
+	fs.BoolVar(&s.dump, "dump", false, "Write out the seed to location table and its inverse")
+	fs.StringVar(&s.out, "out", "", "File to write the table to (default stdout)")
+}
+
+func (s *almanacSolver) Solve(contents string) (string, error) {
+	almanac, err := parseAlmanac(contents)
+	if err != nil {
+		return "", err
+	}
+	seeds, err := s.parseSeeds(almanac.seeds)
+	if err != nil {
+		return "", err
+	}
+
+	locations := almanac.seedToLocation()
+	log.Printf("%d maps composed into %d ranges", len(almanac.maps), len(locations.Mappings()))
+
+	if s.dump {
+		if err := s.writeTables(locations); err != nil {
+			return "", err
+		}
+	}
+
+	if s.seedFor != "" {
+		return s.findSeed(locations, seeds)
+	}
+
+	closest, ok := locations.Image(seeds).Min()
+	if !ok {
+		return "", almanac.seeds.Errorf(almanac.seeds.End(), "at least one seed")
+	}
+	log.Printf("Number: %d", closest)
+	return strconv.FormatInt(closest, 10), nil
+}
+
+func (s *almanacSolver) findSeed(locations *interval.IntervalMap[int64], seeds interval.Set[int64]) (string, error) {
+	location, err := strconv.ParseInt(s.seedFor, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("Cannot parse --seed-for %q: %w", s.seedFor, err)
+	}
+
+	found := locations.Preimage(location)
+	if len(found) == 0 {
+		return "", fmt.Errorf("No seed ends up at location %d", location)
+	}
+	for _, seed := range found {
+		log.Printf("Seed %d ends up at location %d, planted: %v", seed, location, seeds.Contains(seed))
+	}
+	return strconv.FormatInt(found[0], 10), nil
+}
+
+func writeTable(w io.Writer, title string, m *interval.IntervalMap[int64]) {
+	fmt.Fprintf(w, "%s:\n", title)
+	for _, mapping := range m.Mappings() {
+		fmt.Fprintf(w, "  %v -> %v (%+d)\n", mapping.Interval, mapping.Shift(mapping.Offset), mapping.Offset)
+	}
+	fmt.Fprintf(w, "  anything else stays the same\n")
+}
+
+func (s *almanacSolver) writeTables(locations *interval.IntervalMap[int64]) error {
+	out, err := aoc.CreateOutput(s.out)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	writeTable(out, "seed-to-location", locations)
+	seeds, err := locations.Inverse()
+	if errors.Is(err, interval.ErrNotOneToOne) {
+		fmt.Fprintf(out, "location-to-seed:\n  %v\n", err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	writeTable(out, "location-to-seed", seeds)
+	return nil
+}
@@ -1,7 +1,13 @@
-package main
+package day6
 
 import (
 	"log"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
 )
 
 type Race struct {
@@ -9,41 +15,145 @@ type Race struct {
 	distance int64
 }
 
-func main() {
-	races := []*Race{
-		// The sample from part 1
-		// &Race{
-		// 	time: 7,
-		// 	distance: 9,
-		// },
-		// &Race{
-		// 	time: 15,
-		// 	distance: 40,
-		// },
-		// &Race{
-		// 	time: 30,
-		// 	distance: 200,
-		// },
-		// The sample from part 2
-		&Race{
-			time: 71530,
-			distance: 940200,
-		},
-	}
-
-	total := int64(1)
-	for _, race := range races {
-		var won, t int64
-
-		for t = 0; t < race.time; t++ {
-			remainingTime := race.time - t
-			distance := remainingTime * t
-			if distance > race.distance {
-				won++
-			}
+// parseRaces reads the Time: and Distance: lines. When kerned, all the
+// numbers on a line are really one number with bad spacing.
+func parseRaces(contents string, kerned bool) ([]*Race, error) {
+	lines := aoc.SourceLines(strings.TrimRight(contents, "\r\n"), 1)
+	if len(lines) != 2 {
+		last := lines[len(lines)-1]
+		return nil, last.Errorf(last.Whole(), "just a Time: line and a Distance: line")
+	}
+
+	// a race needs time to hold the button, but a record can be nothing
+	times, err := parseLabelled(lines[0], "Time:", kerned, 1)
+	if err != nil {
+		return nil, err
+	}
+	distances, err := parseLabelled(lines[1], "Distance:", kerned, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(times) != len(distances) {
+		return nil, lines[1].Errorf(lines[1].End(), "%d distances to match the times, not %d", len(times), len(distances))
+	}
+
+	var races []*Race
+	for i := range times {
+		races = append(races, &Race{time: times[i], distance: distances[i]})
+	}
+	return races, nil
+}
+
+func parseLabelled(line aoc.SourceLine, label string, kerned bool, least int64) ([]int64, error) {
+	if !strings.HasPrefix(line.Text, label) {
+		return nil, line.Errorf(line.Whole(), "%q then numbers", label)
+	}
+	fields := line.Whole().From(len(label)).Fields()
+	if len(fields) == 0 {
+		return nil, line.Errorf(line.End(), "numbers after %q", label)
+	}
+
+	var values []int64
+	var digits strings.Builder
+	for _, f := range fields {
+		v, err := line.Int(f, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		// kerned fields are only some of the digits, so just the sign matters
+		if v < least && !kerned {
+			return nil, line.Errorf(f, "a number of at least %d", least)
 		}
-		total *= won
+		if v < 0 {
+			return nil, line.Errorf(f, "a number of at least 0")
+		}
+		values = append(values, v)
+		digits.WriteString(f.Text)
+	}
+	if !kerned {
+		return values, nil
+	}
+
+	first, last := fields[0], fields[len(fields)-1]
+	span := line.Whole().Slice(first.Offset, last.Offset+len(last.Text))
+	v, err := strconv.ParseInt(digits.String(), 10, 64)
+	if err != nil {
+		perr := line.Errorf(span, "a number that fits in 64 bits")
+		perr.Err = err
+		return nil, perr
+	}
+	if v < least {
+		return nil, line.Errorf(span, "a number of at least %d", least)
 	}
+	return []int64{v}, nil
+}
+
+// beats checks hold * (time - hold) > distance without overflowing.
+func (r *Race) beats(hold int64) bool {
+	hi, lo := bits.Mul64(uint64(hold), uint64(r.time - hold))
+	return hi > 0 || lo > uint64(r.distance)
+}
+
+// waysToWin solves hold * (time - hold) > distance for the hold times.
+// The winners are every hold strictly between the roots of
+// hold^2 - time*hold + distance, which are symmetric around time/2.
+func (r *Race) waysToWin() int64 {
+	// time^2 can overflow an int64, so the discriminant is done with big ints.
+	t := big.NewInt(r.time)
+	disc := new(big.Int).Mul(t, t)
+	disc.Sub(disc, new(big.Int).Mul(big.NewInt(4), big.NewInt(r.distance)))
+	if disc.Sign() <= 0 {
+		return 0
+	}
+	root := new(big.Int).Sqrt(disc).Int64()
+
+	// The root is floored, so the first winner is within a step of this.
+	first := (r.time - root) / 2
+	for first <= r.time/2 && !r.beats(first) {
+		first++
+	}
+	for first > 0 && r.beats(first-1) {
+		first--
+	}
+	if first > r.time/2 {
+		return 0
+	}
+	return r.time - 2*first + 1
+}
 
-	log.Printf("Number: %d", total)
+// countWaysToWin multiplies the ways to win each race. Each fits in an
+// int64, but the product of several races may not.
+func countWaysToWin(races []*Race) *big.Int {
+	total := big.NewInt(1)
+	for _, race := range races {
+		won := race.waysToWin()
+		log.Printf("Race of %d ms to beat %d mm can be won %d ways", race.time, race.distance, won)
+		total.Mul(total, big.NewInt(won))
+	}
+	return total
+}
+
+func init() {
+	aoc.Register(6, 1, aoc.SolverFunc(solvePart1))
+	aoc.Register(6, 2, aoc.SolverFunc(solvePart2))
+}
+
+func solvePart1(contents string) (string, error) {
+	races, err := parseRaces(contents, false)
+	if err != nil {
+		return "", err
+	}
+	total := countWaysToWin(races)
+	log.Printf("Number: %s", total)
+	return total.String(), nil
+}
+
+func solvePart2(contents string) (string, error) {
+	races, err := parseRaces(contents, true)
+	if err != nil {
+		return "", err
+	}
+	total := countWaysToWin(races)
+	log.Printf("Number: %s", total)
+	return total.String(), nil
 }
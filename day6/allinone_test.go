@@ -0,0 +1,37 @@
+package day6
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWaysToWin(t *testing.T) {
+	tests := []struct {
+		name     string
+		time     int64
+		distance int64
+		want     int64
+	}{
+		{"sample", 7, 9, 4},
+		{"sample record", 30, 200, 9},
+		{"no winners", 7, 12, 0},
+		{"just one winner", 6, 8, 1},
+		{"no record", 7, 0, 6},
+		{"time of one", 1, 0, 0},
+		{"max time", math.MaxInt64, 0, math.MaxInt64 - 1},
+		{"max time and record", math.MaxInt64, math.MaxInt64, math.MaxInt64 - 3},
+	}
+	for _, tt := range tests {
+		race := &Race{time: tt.time, distance: tt.distance}
+		if got := race.waysToWin(); got != tt.want {
+			t.Errorf("%s: wanted %d, got %d", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestCountWaysToWinDoesNotWrap(t *testing.T) {
+	races := []*Race{{time: 10000000000, distance: 1}, {time: 10000000000, distance: 1}}
+	if got, want := countWaysToWin(races).String(), "99999999980000000001"; got != want {
+		t.Errorf("wanted %s, got %s", want, got)
+	}
+}
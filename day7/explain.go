@@ -0,0 +1,90 @@
+package day7
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// handRanking is why a hand ended up where it did in the sorted hands.
+type handRanking struct {
+	rank     int
+	hand     *PokerHand
+	winnings int64
+	// versus is how the hand compares to the one ranked just below it
+	versus string
+}
+
+func (r *Rules) card(rank int) string {
+	return string([]rune(r.order)[rank])
+}
+
+// explainVersus goes through the same steps as the sort in computeWinnings,
+// stopping at whichever one decides it.
+func (r *Rules) explainVersus(hand, below *PokerHand) string {
+	if hand.category != below.category {
+		return fmt.Sprintf("beats %s on category, %s over %s", below.hand, r.categories[hand.category].name, r.categories[below.category].name)
+	}
+
+	var steps []string
+	ca, cb := r.tieBreakCards(hand.cards), r.tieBreakCards(below.cards)
+	for i, x := range ca {
+		y := cb[i]
+		switch {
+		case x == y:
+			steps = append(steps, r.card(x)+"="+r.card(y))
+		case x > y:
+			steps = append(steps, r.card(x)+">"+r.card(y))
+			return fmt.Sprintf("beats %s on cards %s", below.hand, strings.Join(steps, " "))
+		default:
+			// can't happen once sorted, but say so rather than hide it
+			steps = append(steps, r.card(x)+"<"+r.card(y))
+			return fmt.Sprintf("loses to %s on cards %s", below.hand, strings.Join(steps, " "))
+		}
+	}
+	return fmt.Sprintf("ties with %s on cards %s", below.hand, strings.Join(steps, " "))
+}
+
+// rankHands explains every hand after computeWinnings has sorted them.
+func rankHands(hands []*PokerHand, rules *Rules) []handRanking {
+	var ranking []handRanking
+	for i, hand := range hands {
+		versus := "lowest hand"
+		if i > 0 {
+			versus = rules.explainVersus(hand, hands[i-1])
+		}
+		ranking = append(ranking, handRanking{rank: i + 1, hand: hand, winnings: int64(i+1) * hand.bid, versus: versus})
+	}
+	return ranking
+}
+
+func writeExplanation(w io.Writer, ranking []handRanking, rules *Rules) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "rank\thand\tcategory\tplayed as\tbid\twinnings\tversus the hand below")
+	for _, r := range ranking {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d\t%d\t%s\n",
+			r.rank, r.hand.hand, rules.categories[r.hand.category].name, r.hand.played, r.hand.bid, r.winnings, r.versus)
+	}
+	return tw.Flush()
+}
+
+func writeRankingCSV(w io.Writer, ranking []handRanking, rules *Rules) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"rank", "hand", "category", "played", "bid", "winnings", "versus"})
+	for _, r := range ranking {
+		cw.Write([]string{
+			strconv.Itoa(r.rank),
+			r.hand.hand,
+			rules.categories[r.hand.category].name,
+			r.hand.played,
+			strconv.FormatInt(r.hand.bid, 10),
+			strconv.FormatInt(r.winnings, 10),
+			r.versus,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
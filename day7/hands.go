@@ -0,0 +1,95 @@
+package day7
+
+import (
+	"io"
+	"log"
+	"sort"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+type PokerHand struct {
+	hand string
+	// the rank of each card as dealt
+	cards []int
+	// index into the rules' categories
+	category int
+	// played is the hand with every wildcard swapped for what it stands in for
+	played string
+	bid int64
+}
+
+func computeWinnings(hands []*PokerHand, rules *Rules) int64 {
+	sort.Slice(hands, func(a, b int) bool {
+		if hands[a].category != hands[b].category {
+			return hands[a].category < hands[b].category
+		}
+		ca, cb := rules.tieBreakCards(hands[a].cards), rules.tieBreakCards(hands[b].cards)
+		for i, x := range ca {
+			y := cb[i]
+			if x != y {
+				return x < y
+			}
+		}
+		return false
+	})
+
+	var winnings int64
+	for m, hand := range hands {
+		handWinning := (int64(m)+1) * hand.bid
+		log.Printf("%d place is hand %q with bid %d won %d", m+1, hand.hand, hand.bid, handWinning)
+		winnings += handWinning
+	}
+	return winnings
+}
+
+// parseHands streams the hands in, but every hand has to be kept since a
+// hand's rank is not known until all of them are sorted.
+func parseHands(contents io.Reader, rules *Rules) ([]*PokerHand, error) {
+	var hands []*PokerHand
+	err := aoc.EachLine(contents, func(line string, n int) error {
+		hand, err := parseHand(aoc.SourceLine{Number: n, Text: line}, rules)
+		if err != nil {
+			return err
+		}
+		hands = append(hands, hand)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hands, nil
+}
+
+// handSize is how many cards are in every hand, so any two can be compared card by card.
+const handSize = 5
+
+func parseHand(line aoc.SourceLine, rules *Rules) (*PokerHand, error) {
+	parts := line.Whole().Fields()
+	if len(parts) != 2 {
+		return nil, line.Errorf(line.Whole(), "a hand and a bid separated by a space")
+	}
+
+	var cards []int
+	for i, c := range parts[0].Text {
+		v := rules.rank(c)
+		if v < 0 {
+			return nil, line.Errorf(parts[0].Slice(i, i+1), "a card from %q", rules.order)
+		}
+		cards = append(cards, v)
+	}
+	if len(cards) != handSize {
+		return nil, line.Errorf(parts[0], "a hand of %d cards", handSize)
+	}
+
+	bid, err := line.Int(parts[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	category, played := rules.categorize(parts[0].Text)
+	if category < 0 {
+		return nil, line.Errorf(parts[0], "a hand that fits one of the categories")
+	}
+	return &PokerHand{parts[0].Text, cards, category, played, bid}, nil
+}
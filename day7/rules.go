@@ -0,0 +1,276 @@
+package day7
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+// category is a kind of hand, like a full house. fits gets how many of
+// each card are in the hand, most first, and the rank of every card.
+type category struct {
+	name string
+	fits func(counts []int, ranks []int) bool
+}
+
+var allCategories = []category{
+	{"high-card", func(counts, ranks []int) bool { return true }},
+	{"one-pair", func(counts, ranks []int) bool { return counts[0] >= 2 }},
+	{"two-pair", func(counts, ranks []int) bool { return counts[0] >= 2 && len(counts) > 1 && counts[1] >= 2 }},
+	{"three-kind", func(counts, ranks []int) bool { return counts[0] >= 3 }},
+	{"straight", isStraight},
+	{"full-house", func(counts, ranks []int) bool { return counts[0] >= 3 && len(counts) > 1 && counts[1] >= 2 }},
+	{"four-kind", func(counts, ranks []int) bool { return counts[0] >= 4 }},
+	{"five-kind", func(counts, ranks []int) bool { return counts[0] >= 5 }},
+}
+
+// isStraight is every card being one rank above the last once sorted.
+func isStraight(counts, ranks []int) bool {
+	sorted := slices.Clone(ranks)
+	slices.Sort(sorted)
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] != sorted[i-1]+1 {
+			return false
+		}
+	}
+	return true
+}
+
+func findCategory(name string) (category, bool) {
+	for _, c := range allCategories {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return category{}, false
+}
+
+func categoryNames() []string {
+	var names []string
+	for _, c := range allCategories {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// tieBreak is how two hands of the same category are ordered.
+type tieBreak int
+
+const (
+	// TIEBREAK_DEALT compares the cards in the order they were dealt.
+	TIEBREAK_DEALT tieBreak = iota
+	// TIEBREAK_HIGHEST compares the highest card of each, then the next highest and so on.
+	TIEBREAK_HIGHEST
+)
+
+var tieBreakNames = map[string]tieBreak{
+	"dealt":   TIEBREAK_DEALT,
+	"highest": TIEBREAK_HIGHEST,
+}
+
+// Rules are everything that changes between variants of Camel Cards.
+type Rules struct {
+	// order is every card from weakest to strongest. Wildcards rank by
+	// their place in it, and a straight is cards next to each other in it.
+	order string
+	// wildcards stand in for whichever other card makes the best hand.
+	wildcards string
+	// categories from weakest to strongest. A hand is the strongest one it fits.
+	categories []category
+	tieBreak   tieBreak
+}
+
+var standardCategories = []string{"high-card", "one-pair", "two-pair", "three-kind", "full-house", "four-kind", "five-kind"}
+
+func newRules(order string, wildcards string) Rules {
+	rules := Rules{order: order, wildcards: wildcards, tieBreak: TIEBREAK_DEALT}
+	for _, name := range standardCategories {
+		c, _ := findCategory(name)
+		rules.categories = append(rules.categories, c)
+	}
+	return rules
+}
+
+var (
+	standardRules = newRules("23456789TJQKA", "")
+	jokerRules    = newRules("J23456789TQKA", "J")
+)
+
+// set changes one of the rules by name, as used by both the flags and the config file.
+func (r *Rules) set(key, value string) error {
+	switch key {
+	case "order":
+		for i, c := range value {
+			if strings.ContainsRune(value[i+utf8.RuneLen(c):], c) {
+				return fmt.Errorf("Card %q is in the order more than once", c)
+			}
+		}
+		r.order = value
+	case "wildcards":
+		r.wildcards = value
+	case "categories":
+		r.categories = nil
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			c, ok := findCategory(name)
+			if !ok {
+				return fmt.Errorf("Unknown category %q, expected some of %s", name, strings.Join(categoryNames(), ", "))
+			}
+			r.categories = append(r.categories, c)
+		}
+	case "tiebreak":
+		t, ok := tieBreakNames[value]
+		if !ok {
+			return fmt.Errorf("Unknown tiebreak %q, expected dealt or highest", value)
+		}
+		r.tieBreak = t
+	default:
+		return fmt.Errorf("Unknown rule %q, expected order, wildcards, categories or tiebreak", key)
+	}
+	return nil
+}
+
+// validate checks the rules fit together once everything has been set.
+func (r *Rules) validate() error {
+	for _, c := range r.wildcards {
+		if !strings.ContainsRune(r.order, c) {
+			return fmt.Errorf("Wildcard %q needs a place in the card order %q", c, r.order)
+		}
+	}
+	if len(r.categories) == 0 {
+		return fmt.Errorf("Need at least 1 category")
+	}
+	return nil
+}
+
+// loadRules reads a config file of "key = value" lines on top of the rules
+// given, with blank lines and lines starting with # skipped.
+func loadRules(path string, rules Rules) (Rules, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return rules, err
+	}
+	for _, line := range aoc.SourceLines(string(contents), 1) {
+		text := strings.TrimSpace(line.Text)
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, value, found := line.Whole().Cut("=")
+		if !found {
+			perr := line.Errorf(line.Whole(), "%q", "key = value")
+			perr.File = path
+			return rules, perr
+		}
+		if err := rules.set(strings.TrimSpace(key.Text), strings.TrimSpace(value.Text)); err != nil {
+			perr := line.Errorf(line.Whole(), "a valid rule (%v)", err)
+			perr.File = path
+			perr.Err = err
+			return rules, perr
+		}
+	}
+	return rules, nil
+}
+
+// rank is how strong a card is, or -1 if it isn't in the order.
+func (r *Rules) rank(card rune) int {
+	for i, c := range []rune(r.order) {
+		if c == card {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *Rules) isWild(card rune) bool {
+	return strings.ContainsRune(r.wildcards, card)
+}
+
+// categorize finds the strongest category the cards fit. Wildcards are
+// tried as every mix of the other cards, which only depends on how many of
+// each card there are, not which wildcard is which.
+func (r *Rules) categorize(hand string) (int, string) {
+	var fixed []rune
+	var wild []int
+	for i, c := range hand {
+		if r.isWild(c) {
+			wild = append(wild, i)
+		} else {
+			fixed = append(fixed, c)
+		}
+	}
+
+	// strongest first, so the substitute chosen is the highest card that works
+	var substitutes []rune
+	order := []rune(r.order)
+	for i := len(order) - 1; i >= 0; i-- {
+		if !r.isWild(order[i]) {
+			substitutes = append(substitutes, order[i])
+		}
+	}
+	if len(substitutes) == 0 {
+		wild = nil
+		fixed = []rune(hand)
+	}
+
+	best, played := -1, hand
+	cards := make([]rune, len(fixed), len(hand))
+	copy(cards, fixed)
+	var try func(from int)
+	try = func(from int) {
+		if len(cards) == len(fixed)+len(wild) {
+			if c := r.categoryOf(cards); c > best {
+				best = c
+				dealt := []rune(hand)
+				for i, w := range wild {
+					dealt[w] = cards[len(fixed)+i]
+				}
+				played = string(dealt)
+			}
+			return
+		}
+		for i := from; i < len(substitutes); i++ {
+			cards = append(cards, substitutes[i])
+			try(i)
+			cards = cards[:len(cards)-1]
+		}
+	}
+	try(0)
+	return best, played
+}
+
+// categoryOf is the index of the strongest category the cards fit, or -1.
+func (r *Rules) categoryOf(cards []rune) int {
+	perCard := map[rune]int{}
+	ranks := make([]int, len(cards))
+	for i, c := range cards {
+		perCard[c]++
+		ranks[i] = r.rank(c)
+	}
+	var counts []int
+	for _, n := range perCard {
+		counts = append(counts, n)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(counts)))
+
+	for i := len(r.categories) - 1; i >= 0; i-- {
+		if r.categories[i].fits(counts, ranks) {
+			return i
+		}
+	}
+	return -1
+}
+
+// tieBreakCards are the ranks to compare card by card for two hands of the same category.
+func (r *Rules) tieBreakCards(cards []int) []int {
+	if r.tieBreak == TIEBREAK_HIGHEST {
+		sorted := slices.Clone(cards)
+		sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+		return sorted
+	}
+	return cards
+}
@@ -0,0 +1,122 @@
+package day7
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+func TestCategorize(t *testing.T) {
+	twoWild := newRules("23456789TJQKA", "JQ")
+	straights := newRules("J23456789TQKA", "J")
+	if err := straights.set("categories", "high-card, one-pair, straight, full-house"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		rules    Rules
+		hand     string
+		category string
+		played   string
+	}{
+		{"two wildcards make three of a kind", twoWild, "JQ234", "three-kind", "44234"},
+		{"two wildcards make five of a kind", twoWild, "JJQQ5", "five-kind", "55555"},
+		{"only wildcards", twoWild, "JQJQA", "five-kind", "AAAAA"},
+		{"straight", straights, "65432", "straight", "65432"},
+		{"straight at the top", straights, "QKAT9", "straight", "QKAT9"},
+		{"wildcard fills a straight", straights, "2J456", "straight", "23456"},
+		{"wildcard at the end of a straight", straights, "TJQKA", "straight", "T9QKA"},
+		{"not a straight", straights, "23457", "high-card", "23457"},
+		{"full house beats straight", straights, "33J44", "full-house", "33444"},
+	}
+	for _, tt := range tests {
+		category, played := tt.rules.categorize(tt.hand)
+		if got := tt.rules.categories[category].name; got != tt.category || played != tt.played {
+			t.Errorf("%s: wanted %s as %s, got %s as %s", tt.name, tt.hand, tt.category, played, got)
+		}
+	}
+}
+
+func TestTieBreak(t *testing.T) {
+	// both are high cards, but the first is dealt weaker and holds the highest card
+	const hands = "2AKQJ 1\n3KQJT 2\n"
+	for _, tt := range []struct {
+		tiebreak string
+		want     int64
+	}{{"dealt", 1*1 + 2*2}, {"highest", 1*2 + 2*1}} {
+		rules := standardRules
+		if err := rules.set("tiebreak", tt.tiebreak); err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := parseHands(strings.NewReader(hands), &rules)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := computeWinnings(parsed, &rules); got != tt.want {
+			t.Errorf("tiebreak %s: wanted %d, got %d", tt.tiebreak, tt.want, got)
+		}
+	}
+}
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rules.conf")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadRules(t *testing.T) {
+	path := writeConfig(t, "# two wild cards\n\norder = 23456789TJQKA\nwildcards = JQ\ncategories = high-card,straight\ntiebreak = highest\n")
+	rules, err := loadRules(path, standardRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rules.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if rules.wildcards != "JQ" || rules.tieBreak != TIEBREAK_HIGHEST || len(rules.categories) != 2 || rules.categories[1].name != "straight" {
+		t.Errorf("wanted the rules from the file, got %+v", rules)
+	}
+}
+
+func TestLoadRulesErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		line     int
+		expected string
+	}{
+		{"no equals", "# comment\norder 23456\n", 2, `"key = value"`},
+		{"unknown rule", "jokers = J\n", 1, `a valid rule (Unknown rule "jokers", expected order, wildcards, categories or tiebreak)`},
+		{"unknown tiebreak", "\ntiebreak = best\n", 2, `a valid rule (Unknown tiebreak "best", expected dealt or highest)`},
+		{"unknown category", "categories = pair\n", 1, `a valid rule (Unknown category "pair", expected some of high-card, one-pair, two-pair, three-kind, straight, full-house, four-kind, five-kind)`},
+		{"repeated card", "order = 2234\n", 1, `a valid rule (Card '2' is in the order more than once)`},
+	}
+	for _, tt := range tests {
+		path := writeConfig(t, tt.contents)
+		_, err := loadRules(path, standardRules)
+		var perr *aoc.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("%s: wanted a ParseError, got %v", tt.name, err)
+			continue
+		}
+		if perr.File != path || perr.Line != tt.line || perr.Expected != tt.expected {
+			t.Errorf("%s: wanted %s:%d expecting %s, got %v", tt.name, path, tt.line, tt.expected, perr)
+		}
+	}
+
+	rules := standardRules
+	if err := rules.set("wildcards", "*"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rules.validate(); err == nil {
+		t.Errorf("wanted a wildcard missing from the order to fail")
+	}
+}
@@ -0,0 +1,109 @@
+package day7
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+type rulesSolver struct {
+	rules     Rules
+	rulesFile string
+	// overrides are the rules set by flags, applied in order after the file
+	overrides [][2]string
+	explain   bool
+	export    string
+	out       string
+}
+
+func init() {
+	aoc.Register(7, 1, &rulesSolver{rules: standardRules})
+	aoc.Register(7, 2, &rulesSolver{rules: jokerRules})
+}
+
+func (s *rulesSolver) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&s.rulesFile, "rules", "", "Config file of \"key = value\" lines for order, wildcards, categories and tiebreak")
+	override := func(key string) func(string) error {
+		return func(value string) error {
+			s.overrides = append(s.overrides, [2]string{key, value})
+			return nil
+		}
+	}
+	fs.Func("order", "Every card from weakest to strongest (default "+strconv.Quote(s.rules.order)+")", override("order"))
+	fs.Func("wildcards", "Cards that stand in for whichever card makes the best hand (default "+strconv.Quote(s.rules.wildcards)+")", override("wildcards"))
+	fs.Func("categories", "Comma separated hand categories from weakest to strongest, out of "+strings.Join(categoryNames(), ", "), override("categories"))
+	fs.BoolVar(&s.explain, "explain", false, "Log why each hand got its rank")
+	fs.StringVar(&s.export, "export", "", "Write out the full ranking: csv")
+	fs.StringVar(&s.out, "out", "", "File to write the export to (default stdout)")
+	fs.Func("tiebreak", "How hands of the same category are ordered: dealt or highest (default dealt)", override("tiebreak"))
+}
+
+func (s *rulesSolver) loadRules() (*Rules, error) {
+	rules := s.rules
+	if s.rulesFile != "" {
+		var err error
+		rules, err = loadRules(s.rulesFile, rules)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, o := range s.overrides {
+		if err := rules.set(o[0], o[1]); err != nil {
+			return nil, err
+		}
+	}
+	if err := rules.validate(); err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+func (s *rulesSolver) Solve(contents string) (string, error) {
+	return s.SolveStream(strings.NewReader(contents))
+}
+
+func (s *rulesSolver) SolveStream(contents io.Reader) (string, error) {
+	if s.export != "" && s.export != "csv" {
+		return "", fmt.Errorf("Unknown export %q, expected csv", s.export)
+	}
+	rules, err := s.loadRules()
+	if err != nil {
+		return "", err
+	}
+	hands, err := parseHands(contents, rules)
+	if err != nil {
+		return "", err
+	}
+	winnings := computeWinnings(hands, rules)
+
+	if s.explain || s.export != "" {
+		ranking := rankHands(hands, rules)
+		if s.explain {
+			// Alongside the logs, so it never mixes into the answer or a csv on stdout.
+			if err := writeExplanation(log.Writer(), ranking, rules); err != nil {
+				return "", err
+			}
+		}
+		if s.export == "csv" {
+			if err := s.writeExport(ranking, rules); err != nil {
+				return "", err
+			}
+		}
+	}
+	log.Printf("All winnings: %d", winnings)
+	return strconv.FormatInt(winnings, 10), nil
+}
+
+func (s *rulesSolver) writeExport(ranking []handRanking, rules *Rules) error {
+	out, err := aoc.CreateOutput(s.out)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	return writeRankingCSV(out, ranking, rules)
+}
@@ -0,0 +1,212 @@
+package day8
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"slices"
+	"sort"
+)
+
+// ghostState is everything that decides where a ghost goes next.
+type ghostState struct {
+	node  string
+	index int
+}
+
+// ghostCycle is the path of one ghost. Once it's in a state it has been in
+// before it can only go round the same loop forever.
+type ghostCycle struct {
+	start string
+	// cycleStart is the step the loop starts at, and length is how many steps it takes
+	cycleStart int64
+	length     int64
+	// transient are the steps on a Z node before the loop starts
+	transient []int64
+	// cyclic are the steps on a Z node during the first time round the loop
+	cyclic []int64
+	// loop is every state in the loop, in the order they're visited
+	loop []ghostState
+}
+
+func findCycle(start string, nodeMap map[string]Node, path []int) *ghostCycle {
+	cycle := &ghostCycle{start: start}
+	seen := map[ghostState]int64{}
+	var visited []ghostState
+	node := start
+	for step := int64(0); ; step++ {
+		state := ghostState{node: node, index: int(step % int64(len(path)))}
+		if first, ok := seen[state]; ok {
+			cycle.cycleStart = first
+			cycle.length = step - first
+			cycle.loop = visited[first:]
+			break
+		}
+		seen[state] = step
+		visited = append(visited, state)
+		if step > 0 && node[len(node)-1] == 'Z' {
+			cycle.transient = append(cycle.transient, step)
+		}
+		node = nodeMap[node][path[state.index]]
+	}
+
+	// every Z hit was put in transient, so move the ones on the loop over
+	split := sort.Search(len(cycle.transient), func(i int) bool {
+		return cycle.transient[i] >= cycle.cycleStart
+	})
+	cycle.cyclic = cycle.transient[split:]
+	cycle.transient = cycle.transient[:split]
+
+	log.Printf("%s hits Z at %v before a loop of %d steps from step %d, then at %v", start, cycle.transient, cycle.length, cycle.cycleStart, cycle.cyclic)
+	return cycle
+}
+
+// analyzeGhosts finds the cycle of every ghost starting on an A node.
+func analyzeGhosts(nodeMap map[string]Node, path []int) []*ghostCycle {
+	var starts []string
+	for n := range nodeMap {
+		if n[len(n)-1] == 'A' {
+			starts = append(starts, n)
+		}
+	}
+	sort.Strings(starts)
+
+	var cycles []*ghostCycle
+	for _, start := range starts {
+		cycles = append(cycles, findCycle(start, nodeMap, path))
+	}
+	return cycles
+}
+
+// lcmBreaks says why the cycle lengths alone can't give the answer, or ""
+// if they can. That needs each ghost to hit exactly one Z, once per loop,
+// on the last step of a loop that is as long as the path to it.
+func (c *ghostCycle) lcmBreaks() string {
+	switch {
+	case len(c.transient) > 0:
+		return fmt.Sprintf("%s hits Z at %v before its loop starts", c.start, c.transient)
+	case len(c.cyclic) != 1:
+		return fmt.Sprintf("%s hits Z %d times each time round its loop", c.start, len(c.cyclic))
+	case c.cyclic[0] != c.length:
+		return fmt.Sprintf("%s first hits Z at step %d but its loop is %d steps", c.start, c.cyclic[0], c.length)
+	}
+	return ""
+}
+
+// hitsAt is whether the ghost is on a Z node after step steps.
+func (c *ghostCycle) hitsAt(step int64) bool {
+	if slices.Contains(c.transient, step) {
+		return true
+	}
+	for _, hit := range c.cyclic {
+		if step >= hit && (step-hit)%c.length == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// congruence is every step that is residue mod modulus, from atLeast on.
+type congruence struct {
+	residue *big.Int
+	modulus *big.Int
+	atLeast int64
+}
+
+// merge is the generalised Chinese remainder theorem, which works even
+// when the moduli share a factor, as long as the residues agree on it.
+func (a congruence) merge(b congruence) (congruence, bool) {
+	g := new(big.Int).GCD(nil, nil, a.modulus, b.modulus)
+	diff := new(big.Int).Sub(b.residue, a.residue)
+	if new(big.Int).Mod(diff, g).Sign() != 0 {
+		return congruence{}, false
+	}
+
+	// solve a.residue + a.modulus*k = b.residue mod b.modulus for k
+	m := new(big.Int).Quo(a.modulus, g)
+	n := new(big.Int).Quo(b.modulus, g)
+	k := new(big.Int).Quo(diff, g)
+	if n.Cmp(big.NewInt(1)) != 0 {
+		k.Mul(k, new(big.Int).ModInverse(m, n))
+	}
+	k.Mod(k, n)
+
+	modulus := new(big.Int).Mul(m, b.modulus)
+	residue := new(big.Int).Mul(a.modulus, k)
+	residue.Add(residue, a.residue)
+	residue.Mod(residue, modulus)
+	return congruence{residue: residue, modulus: modulus, atLeast: max(a.atLeast, b.atLeast)}, true
+}
+
+// first is the earliest step the congruence allows.
+func (c congruence) first() *big.Int {
+	step := new(big.Int).Set(c.residue)
+	lower := big.NewInt(c.atLeast)
+	if step.Cmp(lower) < 0 {
+		// round up to the next step at or after lower
+		gap := new(big.Int).Sub(lower, step)
+		gap.Add(gap, c.modulus)
+		gap.Sub(gap, big.NewInt(1))
+		gap.Quo(gap, c.modulus)
+		step.Add(step, gap.Mul(gap, c.modulus))
+	}
+	return step
+}
+
+var errNeverTogether = errors.New("The ghosts are never all on a Z node at the same time")
+
+// arrivalStep is the first step every ghost is on a Z node at once.
+func arrivalStep(cycles []*ghostCycle) (*big.Int, error) {
+	var best *big.Int
+	consider := func(step *big.Int) {
+		if best == nil || step.Cmp(best) < 0 {
+			best = step
+		}
+	}
+
+	// A Z hit before some ghost's loop only happens once, so just check it against the others.
+	for _, c := range cycles {
+		for _, step := range c.transient {
+			if allHitAt(cycles, step) {
+				consider(big.NewInt(step))
+			}
+		}
+	}
+
+	// Otherwise it's a Z hit in every ghost's loop, so combine each choice of hit.
+	var solutions []congruence
+	for i, c := range cycles {
+		var next []congruence
+		for _, hit := range c.cyclic {
+			own := congruence{residue: big.NewInt(hit % c.length), modulus: big.NewInt(c.length), atLeast: hit}
+			if i == 0 {
+				next = append(next, own)
+				continue
+			}
+			for _, s := range solutions {
+				if merged, ok := s.merge(own); ok {
+					next = append(next, merged)
+				}
+			}
+		}
+		solutions = next
+	}
+	for _, s := range solutions {
+		consider(s.first())
+	}
+
+	if best == nil {
+		return nil, errNeverTogether
+	}
+	return best, nil
+}
+
+func allHitAt(cycles []*ghostCycle, step int64) bool {
+	for _, c := range cycles {
+		if !c.hitsAt(step) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package day8
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// ghostNetwork builds a network that always goes left, where each ghost
+// follows its chain of nodes and the last one leads back to the node given.
+func ghostNetwork(t *testing.T, chains map[string][]string) (map[string]Node, []int) {
+	t.Helper()
+	var b strings.Builder
+	b.WriteString("L\n\n")
+	for back, chain := range chains {
+		for i, node := range chain {
+			next := back
+			if i+1 < len(chain) {
+				next = chain[i+1]
+			}
+			fmt.Fprintf(&b, "%s = (%s, %s)\n", node, next, next)
+		}
+	}
+	path, nodeMap, err := parseNetwork(b.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return nodeMap, path
+}
+
+// bruteForceArrival walks every ghost a step at a time, giving up after limit steps.
+func bruteForceArrival(nodeMap map[string]Node, path []int, limit int) (int, bool) {
+	var ghosts []string
+	for n := range nodeMap {
+		if strings.HasSuffix(n, "A") {
+			ghosts = append(ghosts, n)
+		}
+	}
+	for step := 1; step <= limit; step++ {
+		all := true
+		for i, g := range ghosts {
+			ghosts[i] = nodeMap[g][path[(step-1)%len(path)]]
+			all = all && strings.HasSuffix(ghosts[i], "Z")
+		}
+		if all {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func TestArrivalStep(t *testing.T) {
+	tests := []struct {
+		name string
+		// each chain is keyed by the node its last node leads back to
+		chains map[string][]string
+		want   int
+	}{
+		{"transient Z hit", map[string][]string{
+			"7CC": {"7AA", "7BZ", "7CC", "7DD"},
+			"8BZ": {"8AA", "8BZ"},
+		}, 1},
+		{"two Z hits per loop", map[string][]string{
+			"1BB": {"1AA", "1BB", "1CZ", "1DD", "1EZ"},
+			"2BB": {"2AA", "2BB", "2CC", "2DZ"},
+		}, 6},
+		{"Z hit not at the end of the loop", map[string][]string{
+			"3BZ": {"3AA", "3BZ", "3CC", "3DD"},
+			"4BB": {"4AA", "4BB", "4CZ"},
+		}, 4},
+		{"loops sharing a factor", map[string][]string{
+			"5BB": {"5AA", "5BB", "5CC", "5DD", "5EZ", "5FF", "5GG"},
+			"6BB": {"6AA", "6BB", "6CZ", "6DD", "6EE"},
+		}, 10},
+	}
+	for _, tt := range tests {
+		nodeMap, path := ghostNetwork(t, tt.chains)
+		cycles := analyzeGhosts(nodeMap, path)
+
+		broken := false
+		for _, c := range cycles {
+			broken = broken || c.lcmBreaks() != ""
+		}
+		if !broken {
+			t.Errorf("%s: wanted the LCM shortcut not to hold", tt.name)
+		}
+
+		want, ok := bruteForceArrival(nodeMap, path, 10000)
+		if !ok {
+			t.Fatalf("%s: brute force never arrived", tt.name)
+		}
+		if want != tt.want {
+			t.Errorf("%s: brute force took %d steps, not %d", tt.name, want, tt.want)
+		}
+		got, err := arrivalStep(cycles)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got.Int64() != int64(want) {
+			t.Errorf("%s: wanted %d steps, got %s", tt.name, want, got)
+		}
+	}
+}
+
+func TestNeverTogether(t *testing.T) {
+	// one ghost is on Z every odd step and the other every even step
+	nodeMap, path := ghostNetwork(t, map[string][]string{
+		"5BZ": {"5AA", "5BZ", "5CC"},
+		"6BB": {"6AA", "6BB", "6CZ"},
+	})
+	if step, ok := bruteForceArrival(nodeMap, path, 1000); ok {
+		t.Fatalf("brute force arrived at step %d", step)
+	}
+	if _, err := arrivalStep(analyzeGhosts(nodeMap, path)); !errors.Is(err, errNeverTogether) {
+		t.Errorf("wanted errNeverTogether, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package day8
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+// exportOptions are the flags shared by both parts for writing out the network.
+type exportOptions struct {
+	export string
+	out    string
+}
+
+func (o *exportOptions) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.export, "export", "", "Write out the network: dot for Graphviz, json for an adjacency list")
+	fs.StringVar(&o.out, "out", "", "File to write the export to (default stdout)")
+}
+
+func (o *exportOptions) validate() error {
+	if o.export != "" && o.export != "dot" && o.export != "json" {
+		return fmt.Errorf("Unknown export %q, expected dot or json", o.export)
+	}
+	return nil
+}
+
+// network is everything an export needs to know about the map.
+type network struct {
+	path    []int
+	nodeMap map[string]Node
+	starts  []string
+	ends    []string
+	cycles  []*ghostCycle
+}
+
+// newNetwork takes the cycles the solver already found, one per start.
+func newNetwork(path []int, nodeMap map[string]Node, cycles []*ghostCycle, isEnd func(string) bool) *network {
+	n := &network{path: path, nodeMap: nodeMap, cycles: cycles}
+	for _, c := range cycles {
+		n.starts = append(n.starts, c.start)
+	}
+	for _, name := range n.names() {
+		if isEnd(name) {
+			n.ends = append(n.ends, name)
+		}
+	}
+	return n
+}
+
+func (n *network) names() []string {
+	var names []string
+	for name := range n.nodeMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (o *exportOptions) writeExport(n *network) error {
+	if o.export == "" {
+		return nil
+	}
+	out, err := aoc.CreateOutput(o.out)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if o.export == "dot" {
+		return n.writeDot(out)
+	}
+	return n.writeJSON(out)
+}
+
+var directionLabels = []string{"L", "R"}
+
+// cycleColors are handed out to the ghosts in turn.
+var cycleColors = []string{"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b", "#e377c2", "#17becf"}
+
+type edge struct {
+	from      string
+	direction int
+}
+
+func (n *network) writeDot(w io.Writer) error {
+	// which ghosts' loops take each edge
+	colors := map[edge][]string{}
+	for i, c := range n.cycles {
+		color := cycleColors[i%len(cycleColors)]
+		taken := map[edge]bool{}
+		for _, state := range c.loop {
+			e := edge{from: state.node, direction: n.path[state.index]}
+			if !taken[e] {
+				taken[e] = true
+				colors[e] = append(colors[e], color)
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph network {\n")
+	b.WriteString("  node [shape=ellipse, fontname=\"monospace\"];\n")
+	for _, name := range n.starts {
+		fmt.Fprintf(&b, "  %q [style=filled, fillcolor=\"palegreen\"];\n", name)
+	}
+	for _, name := range n.ends {
+		fmt.Fprintf(&b, "  %q [style=filled, fillcolor=\"lightcoral\"];\n", name)
+	}
+	for _, name := range n.names() {
+		for direction, to := range n.nodeMap[name] {
+			attrs := fmt.Sprintf("label=%q", directionLabels[direction])
+			if c := colors[edge{from: name, direction: direction}]; len(c) > 0 {
+				attrs += fmt.Sprintf(", color=%q, penwidth=2", strings.Join(c, ":"))
+			}
+			fmt.Fprintf(&b, "  %q -> %q [%s];\n", name, to, attrs)
+		}
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type jsonNetwork struct {
+	Path      string                       `json:"path"`
+	Starts    []string                     `json:"starts"`
+	Ends      []string                     `json:"ends"`
+	Adjacency map[string]map[string]string `json:"adjacency"`
+}
+
+func (n *network) writeJSON(w io.Writer) error {
+	var path strings.Builder
+	for _, direction := range n.path {
+		path.WriteString(directionLabels[direction])
+	}
+
+	adjacency := map[string]map[string]string{}
+	for name, node := range n.nodeMap {
+		adjacency[name] = map[string]string{"L": node[0], "R": node[1]}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jsonNetwork{Path: path.String(), Starts: n.starts, Ends: n.ends, Adjacency: adjacency})
+}
@@ -0,0 +1,94 @@
+package day8
+
+import (
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+// it's a pair for left/right destinations, but I wanted to minimize branches for no reason.
+type Node []string
+
+// parseNetwork reads the travel path on the first line and the nodes after the blank line.
+func parseNetwork(contents string) ([]int, map[string]Node, error) {
+	lines := aoc.SourceLines(contents, 1)
+	path, err := parseTravelPath(lines[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(lines) < 3 {
+		last := lines[len(lines)-1]
+		return nil, nil, last.Errorf(last.End(), "a blank line and then the nodes")
+	}
+	if len(strings.TrimSpace(lines[1].Text)) != 0 {
+		return nil, nil, lines[1].Errorf(lines[1].Whole(), "a blank line after the travel path")
+	}
+	nodeMap, err := parseMap(lines[2:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return path, nodeMap, nil
+}
+
+func parseTravelPath(line aoc.SourceLine) ([]int, error) {
+	var path []int
+	field := line.Whole().TrimSpace()
+	for i, c := range field.Text {
+		if c == 'L' {
+			path = append(path, 0)
+		} else if c == 'R' {
+			path = append(path, 1)
+		} else {
+			return nil, line.Errorf(field.Slice(i, i+1), "L or R")
+		}
+	}
+	if len(path) == 0 {
+		return nil, line.Errorf(field, "a travel path of L and R")
+	}
+	return path, nil
+}
+
+func parseMap(lines []aoc.SourceLine) (map[string]Node, error) {
+	m := map[string]Node{}
+	// every left and right, to check they all lead somewhere once every node is read
+	type reference struct {
+		line  aoc.SourceLine
+		field aoc.Field
+	}
+	var references []reference
+	for _, line := range lines {
+		whole := line.Whole().TrimSpace()
+		if len(whole.Text) == 0 {
+			continue
+		}
+
+		node, rest, found := whole.Cut(" = ")
+		if !found {
+			return nil, line.Errorf(whole, "%q", "AAA = (BBB, CCC)")
+		}
+		if !strings.HasPrefix(rest.Text, "(") {
+			return nil, line.Errorf(rest, "%q", "(")
+		}
+		if !strings.HasSuffix(rest.Text, ")") {
+			return nil, line.Errorf(line.End(), "%q", ")")
+		}
+		left, right, found := rest.Slice(1, len(rest.Text)-1).Cut(", ")
+		if !found {
+			return nil, line.Errorf(rest, "a left and right node separated by %q", ", ")
+		}
+		for _, f := range []aoc.Field{node, left, right} {
+			if len(f.Text) == 0 {
+				return nil, line.Errorf(f, "a node name")
+			}
+		}
+		m[node.Text] = []string{left.Text, right.Text}
+		references = append(references, reference{line, left}, reference{line, right})
+	}
+
+	for _, r := range references {
+		if _, ok := m[r.field.Text]; !ok {
+			return nil, r.line.Errorf(r.field, "a node defined in the network")
+		}
+	}
+	return m, nil
+}
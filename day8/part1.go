@@ -1,39 +1,13 @@
-package main
+package day8
 
 import (
 	"flag"
+	"fmt"
 	"log"
-	"os"
-	"strings"
-)
-
-var filePath = flag.String("file", "part1test.txt", "File path")
-
-// it's a pair for left/right destinations, but I wanted to minimize branches for no reason.
-type Node []string
+	"strconv"
 
-func parseTravelPath(contents string) []int {
-	var path []int
-	for _, c := range strings.TrimSpace(contents) {
-		if c == 'L' {
-			path = append(path, 0)
-		} else {
-			path = append(path, 1)
-		}
-	}
-	return path
-}
-
-func parseMap(lines []string) map[string]Node {
-	m := map[string]Node{}
-	for _, line := range lines {
-		node := line[0:3]
-		left := line[7:10]
-		right := line[12:15]
-		m[node] = []string{left, right}
-	}
-	return m
-}
+	"github.com/HallM/aoc2023/aoc"
+)
 
 func traverse(startNode, targetNode string, nodeMap map[string]Node, path []int) int {
 	var steps int
@@ -59,24 +33,41 @@ func traverse(startNode, targetNode string, nodeMap map[string]Node, path []int)
 	}
 }
 
-func main() {
-	flag.Parse()
+type pathSolver struct {
+	exportOptions
+}
+
+func init() {
+	aoc.Register(8, 1, &pathSolver{})
+}
 
-	if *filePath == "" {
-		log.Fatalf("Must specify the file!")
-	}
+func (s *pathSolver) RegisterFlags(fs *flag.FlagSet) {
+	s.registerFlags(fs)
+}
 
-	contents, err := os.ReadFile(*filePath)
+func (s *pathSolver) Solve(contents string) (string, error) {
+	if err := s.validate(); err != nil {
+		return "", err
+	}
+	path, nodeMap, err := parseNetwork(contents)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
+	}
+	for _, name := range []string{"AAA", "ZZZ"} {
+		if _, ok := nodeMap[name]; !ok {
+			return "", fmt.Errorf("Node %s is not in the network", name)
+		}
+	}
+	if s.export != "" {
+		isEnd := func(n string) bool { return n == "ZZZ" }
+		cycles := []*ghostCycle{findCycle("AAA", nodeMap, path)}
+		if err := s.writeExport(newNetwork(path, nodeMap, cycles, isEnd)); err != nil {
+			return "", err
+		}
 	}
-
-	lines := strings.Split(string(contents), "\n")
-
-	path := parseTravelPath(lines[0])
-	nodeMap := parseMap(lines[2:])
 
 	steps := traverse("AAA", "ZZZ", nodeMap, path)
 
 	log.Printf("Made it in steps: %d", steps)
+	return strconv.Itoa(steps), nil
 }
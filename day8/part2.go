@@ -1,84 +1,54 @@
-package main
+package day8
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"log"
-	"os"
+	"math/big"
+	"strconv"
 	"strings"
-)
-
-var filePath = flag.String("file", "part2test.txt", "File path")
-
-// it's a pair for left/right destinations, but I wanted to minimize branches for no reason.
-type Node []string
 
-func parseTravelPath(contents string) []int {
-	var path []int
-	for _, c := range strings.TrimSpace(contents) {
-		if c == 'L' {
-			path = append(path, 0)
-		} else {
-			path = append(path, 1)
-		}
-	}
-	return path
-}
+	"github.com/HallM/aoc2023/aoc"
+	"github.com/HallM/aoc2023/checked"
+)
 
-func parseMap(lines []string) map[string]Node {
-	m := map[string]Node{}
-	for _, line := range lines {
-		node := line[0:3]
-		left := line[7:10]
-		right := line[12:15]
-		m[node] = []string{left, right}
+// loopLengths are the steps for each ghost to go once round its loop.
+func loopLengths(cycles []*ghostCycle) []int64 {
+	var lengths []int64
+	for _, c := range cycles {
+		lengths = append(lengths, c.length)
 	}
-	return m
+	return lengths
 }
 
-func traverse(startNode string, nodeMap map[string]Node, path []int) int {
-	var steps int
-	node := startNode
-	for {
-		nextPath := steps % len(path)
-		next := nodeMap[node][path[nextPath]]
-		node = next
-
-		steps++
-		if node[2] == 'Z' {
-			return steps
-		}
-
-		if steps > 1000000 {
-			return -1
+func lcmAll(steps []int64) (int64, error) {
+	a := steps[0]
+	for _, b := range steps[1:] {
+		next, err := lcmTwo(a, b)
+		if err != nil {
+			return 0, err
 		}
+		a = next
 	}
+	return a, nil
 }
 
-func traverseAll(nodeMap map[string]Node, path []int) int64 {
-	var s []int64
-	for n := range nodeMap {
-		if n[2] == 'A' {
-			steps := traverse(n, nodeMap, path)
-			log.Printf("%s made it in %d", n, steps)
-			s = append(s, int64(steps))
-		}
-	}
-	return lcmAll(s)
+// Dividing first keeps the intermediate no bigger than the answer.
+func lcmTwo(a, b int64) (int64, error) {
+	return checked.Mul(a / gcd(a, b), b)
 }
 
-func lcmAll(steps []int64) int64 {
-	a := steps[0]
-	for _, b := range steps[1:] {
-		next := lcmTwo(a, b)
-		a = next
+func lcmAllBig(steps []int64) *big.Int {
+	a := big.NewInt(steps[0])
+	for _, s := range steps[1:] {
+		b := big.NewInt(s)
+		g := new(big.Int).GCD(nil, nil, a, b)
+		a.Mul(a.Div(a, g), b)
 	}
 	return a
 }
 
-func lcmTwo(a, b int64) int64 {
-	return (a * b) / gcd(a, b)
-}
-
 func gcd(a, b int64) int64 {
 	var rem int64
 	for {
@@ -92,24 +62,75 @@ func gcd(a, b int64) int64 {
 	return a
 }
 
-func main() {
-	flag.Parse()
+type ghostSolver struct {
+	bigint bool
+	exportOptions
+}
 
-	if *filePath == "" {
-		log.Fatalf("Must specify the file!")
-	}
+func init() {
+	aoc.Register(8, 2, &ghostSolver{})
+}
 
-	contents, err := os.ReadFile(*filePath)
+func (s *ghostSolver) RegisterFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&s.bigint, "bigint", false, "Use arbitrary precision instead of failing when the answer overflows an int64")
+	s.registerFlags(fs)
+}
+
+func (s *ghostSolver) Solve(contents string) (string, error) {
+	if err := s.validate(); err != nil {
+		return "", err
+	}
+	path, nodeMap, err := parseNetwork(contents)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
+	}
+	cycles := analyzeGhosts(nodeMap, path)
+	if s.export != "" {
+		isEnd := func(n string) bool { return strings.HasSuffix(n, "Z") }
+		if err := s.writeExport(newNetwork(path, nodeMap, cycles, isEnd)); err != nil {
+			return "", err
+		}
+	}
+	if len(cycles) == 0 {
+		return "", errors.New("No ghosts start on an A node")
 	}
 
-	lines := strings.Split(string(contents), "\n")
-
-	path := parseTravelPath(lines[0])
-	nodeMap := parseMap(lines[2:])
+	var reasons []string
+	for _, c := range cycles {
+		if reason := c.lcmBreaks(); reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+	if len(reasons) > 0 {
+		log.Printf("The LCM of the loop lengths is not the answer: %s", strings.Join(reasons, "; "))
+		return s.solveCRT(cycles)
+	}
+	log.Printf("Every ghost hits Z once at the end of each loop, so the answer is the LCM of the loop lengths")
 
-	common := traverseAll(nodeMap, path)
+	steps := loopLengths(cycles)
+	if s.bigint {
+		common := lcmAllBig(steps)
+		log.Printf("Made it in steps: %s", common)
+		return common.String(), nil
+	}
 
+	common, err := lcmAll(steps)
+	if err != nil {
+		return "", fmt.Errorf("%w, try --bigint", err)
+	}
 	log.Printf("Made it in steps: %d", common)
+	return strconv.FormatInt(common, 10), nil
+}
+
+// solveCRT finds when the ghosts meet when the LCM shortcut doesn't hold.
+func (s *ghostSolver) solveCRT(cycles []*ghostCycle) (string, error) {
+	arrival, err := arrivalStep(cycles)
+	if err != nil {
+		return "", err
+	}
+	if !s.bigint && !arrival.IsInt64() {
+		return "", fmt.Errorf("%w, the ghosts meet at step %s, try --bigint", checked.ErrOverflow, arrival)
+	}
+	log.Printf("Made it in steps: %s", arrival)
+	return arrival.String(), nil
 }
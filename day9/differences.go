@@ -0,0 +1,142 @@
+package day9
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var errNeverZero = errors.New("Differences never reach an all-zero row")
+
+// DifferenceTable is the history along with each row of differences under
+// it, down to the first row that is all zeros. The differences of int64s
+// can be twice as big, so the rows are big ints.
+type DifferenceTable struct {
+	rows [][]*big.Int
+}
+
+func newDifferenceTable(history []int64) (*DifferenceTable, error) {
+	first := make([]*big.Int, len(history))
+	for i, x := range history {
+		first[i] = big.NewInt(x)
+	}
+	t := &DifferenceTable{rows: [][]*big.Int{first}}
+	for {
+		last := t.rows[len(t.rows)-1]
+		allzero := true
+		for _, x := range last {
+			allzero = allzero && x.Sign() == 0
+		}
+		if allzero && len(last) > 0 {
+			return t, nil
+		}
+		// A single number left that isn't 0 means no row of differences ever will be.
+		if len(last) <= 1 {
+			return nil, errNeverZero
+		}
+
+		diffs := make([]*big.Int, len(last)-1)
+		for j := range diffs {
+			diffs[j] = new(big.Int).Sub(last[j+1], last[j])
+		}
+		t.rows = append(t.rows, diffs)
+	}
+}
+
+// degree is the degree of the polynomial the history fits.
+func (t *DifferenceTable) degree() int {
+	return len(t.rows) - 2
+}
+
+// valueAt is the polynomial at x, where the first number in the history is
+// at 0. It's Newton's forward difference formula, the sum of each leading
+// difference times x choose its row, which works just as well for negative x.
+func (t *DifferenceTable) valueAt(x int64) *big.Int {
+	total := new(big.Int)
+	choose := big.NewInt(1)
+	bx := big.NewInt(x)
+	for j, row := range t.rows {
+		if j > 0 {
+			// x choose j = (x choose j-1) * (x - j + 1) / j, which always divides exactly
+			choose.Mul(choose, new(big.Int).Sub(bx, big.NewInt(int64(j-1))))
+			choose.Quo(choose, big.NewInt(int64(j)))
+		}
+		total.Add(total, new(big.Int).Mul(choose, row[0]))
+	}
+	return total
+}
+
+// extrapolate is the value steps past the end of the history, or before
+// the start of it when backward.
+func (t *DifferenceTable) extrapolate(steps int64, backward bool) *big.Int {
+	if backward {
+		return t.valueAt(-steps)
+	}
+	return t.valueAt(int64(len(t.rows[0])-1) + steps)
+}
+
+// coefficients are the polynomial in powers of x, constant first. Each x
+// choose j is expanded from x(x-1)...(x-j+1) / j!, so they are fractions.
+func (t *DifferenceTable) coefficients() []*big.Rat {
+	coeffs := make([]*big.Rat, t.degree()+1)
+	for i := range coeffs {
+		coeffs[i] = new(big.Rat)
+	}
+
+	// falling is x(x-1)...(x-j+1) in powers of x, constant first
+	falling := []*big.Int{big.NewInt(1)}
+	factorial := big.NewInt(1)
+	for j := 0; j <= t.degree(); j++ {
+		if j > 0 {
+			falling = multiplyByRoot(falling, int64(j-1))
+			factorial.Mul(factorial, big.NewInt(int64(j)))
+		}
+		lead := t.rows[j][0]
+		for i, f := range falling {
+			term := new(big.Rat).SetFrac(new(big.Int).Mul(f, lead), factorial)
+			coeffs[i].Add(coeffs[i], term)
+		}
+	}
+	return coeffs
+}
+
+// multiplyByRoot multiplies the polynomial by (x - root).
+func multiplyByRoot(poly []*big.Int, root int64) []*big.Int {
+	next := make([]*big.Int, len(poly)+1)
+	for i := range next {
+		next[i] = new(big.Int)
+	}
+	r := big.NewInt(root)
+	for i, c := range poly {
+		next[i+1].Add(next[i+1], c)
+		next[i].Sub(next[i], new(big.Int).Mul(c, r))
+	}
+	return next
+}
+
+func formatPolynomial(coeffs []*big.Rat) string {
+	var terms []string
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		c := coeffs[i]
+		if c.Sign() == 0 {
+			continue
+		}
+		term := c.RatString()
+		if i > 0 && (term == "1" || term == "-1") {
+			term = strings.TrimSuffix(term, "1")
+		}
+		switch i {
+		case 0:
+		case 1:
+			term += "x"
+		default:
+			term += fmt.Sprintf("x^%d", i)
+		}
+		terms = append(terms, term)
+	}
+	if len(terms) == 0 {
+		return "0"
+	}
+	return strings.ReplaceAll(strings.Join(terms, " + "), "+ -", "- ")
+}
@@ -0,0 +1,70 @@
+package day9
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExtrapolate(t *testing.T) {
+	tests := []struct {
+		history  []int64
+		steps    int64
+		backward bool
+		want     string
+	}{
+		{[]int64{0, 3, 6, 9, 12, 15}, 1, false, "18"},
+		{[]int64{0, 3, 6, 9, 12, 15}, 0, false, "15"},
+		{[]int64{0, 3, 6, 9, 12, 15}, 0, true, "0"},
+		{[]int64{1, 3, 6, 10, 15, 21}, 3, false, "45"},
+		{[]int64{1, 3, 6, 10, 15, 21}, 1, true, "0"},
+		{[]int64{1, 3, 6, 10, 15, 21}, 3, true, "1"},
+		{[]int64{10, 13, 16, 21, 30, 45}, 1, true, "5"},
+		{[]int64{10, 13, 16, 21, 30, 45}, 2, false, "101"},
+		{[]int64{7, 7}, 5, false, "7"},
+		{[]int64{0}, 5, true, "0"},
+		// the differences of these don't fit in an int64
+		{[]int64{-8000000000000000000, 8000000000000000000, 8000000000000000000, -8000000000000000000}, 1, false, "-40000000000000000000"},
+	}
+	for _, tt := range tests {
+		table, err := newDifferenceTable(tt.history)
+		if err != nil {
+			t.Errorf("%v: %v", tt.history, err)
+			continue
+		}
+		if got := table.extrapolate(tt.steps, tt.backward).String(); got != tt.want {
+			t.Errorf("%v %d steps (backward %v): wanted %s, got %s", tt.history, tt.steps, tt.backward, tt.want, got)
+		}
+	}
+}
+
+func TestPolynomial(t *testing.T) {
+	tests := []struct {
+		history []int64
+		want    string
+	}{
+		{[]int64{0, 0, 0}, "0"},
+		{[]int64{5, 5, 5}, "5"},
+		{[]int64{0, 3, 6, 9, 12, 15}, "3x"},
+		{[]int64{4, 3, 2, 1}, "-x + 4"},
+		{[]int64{1, 3, 6, 10, 15, 21}, "1/2x^2 + 3/2x + 1"},
+		{[]int64{10, 13, 16, 21, 30, 45}, "1/3x^3 - x^2 + 11/3x + 10"},
+	}
+	for _, tt := range tests {
+		table, err := newDifferenceTable(tt.history)
+		if err != nil {
+			t.Errorf("%v: %v", tt.history, err)
+			continue
+		}
+		if got := formatPolynomial(table.coefficients()); got != tt.want {
+			t.Errorf("%v: wanted %q, got %q", tt.history, tt.want, got)
+		}
+	}
+}
+
+func TestNeverZero(t *testing.T) {
+	for _, history := range [][]int64{{1}, {0, 1}, {1, 2, 4, 8}} {
+		if _, err := newDifferenceTable(history); !errors.Is(err, errNeverZero) {
+			t.Errorf("%v: wanted errNeverZero, got %v", history, err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package day9
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+type Line struct {
+	history []int64
+}
+
+func parseLine(l aoc.SourceLine) (*Line, error) {
+	var history []int64
+	for _, n := range l.Whole().Fields() {
+		v, err := l.Int(n, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, v)
+	}
+	if len(history) == 0 {
+		return nil, l.Errorf(l.End(), "a list of numbers")
+	}
+	return &Line{history}, nil
+}
+
+type extrapolateSolver struct {
+	steps int64
+	backward bool
+	polynomial bool
+}
+
+func init() {
+	aoc.Register(9, 1, &extrapolateSolver{steps: 1})
+	aoc.Register(9, 2, &extrapolateSolver{steps: 1, backward: true})
+}
+
+func (s *extrapolateSolver) RegisterFlags(fs *flag.FlagSet) {
+	fs.Int64Var(&s.steps, "steps", s.steps, "How many steps past the history to extrapolate")
+	fs.BoolVar(&s.backward, "backward", s.backward, "Extrapolate before the start of the history instead of after the end")
+	fs.BoolVar(&s.polynomial, "polynomial", false, "Log the polynomial each history fits")
+}
+
+func (s *extrapolateSolver) Solve(contents string) (string, error) {
+	return s.SolveStream(strings.NewReader(contents))
+}
+
+// SolveStream adds up the extrapolated value of each line as it is read.
+func (s *extrapolateSolver) SolveStream(contents io.Reader) (string, error) {
+	if s.steps < 0 {
+		return "", errors.New("Need at least 0 steps, use --backward to go the other way")
+	}
+
+	total := new(big.Int)
+	err := aoc.EachLine(contents, func(text string, n int) error {
+		line := aoc.SourceLine{Number: n, Text: text}
+		l, err := parseLine(line)
+		if err != nil {
+			return err
+		}
+		table, err := newDifferenceTable(l.history)
+		if errors.Is(err, errNeverZero) {
+			return line.Errorf(line.Whole(), "a history whose differences reach all zeros")
+		}
+		if s.polynomial {
+			log.Printf("Row %d fits p(x) = %s", n, formatPolynomial(table.coefficients()))
+		}
+		extrap := table.extrapolate(s.steps, s.backward)
+		log.Printf("Row %d, extrapolated %s", n, extrap)
+		total.Add(total, extrap)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("Sum: %s", total)
+	return total.String(), nil
+}
@@ -0,0 +1,23 @@
+// Package days imports every day so their solvers get registered with aoc.
+package days
+
+import (
+	_ "github.com/HallM/aoc2023/day1"
+	_ "github.com/HallM/aoc2023/day10"
+	_ "github.com/HallM/aoc2023/day11"
+	_ "github.com/HallM/aoc2023/day12"
+	_ "github.com/HallM/aoc2023/day13"
+	_ "github.com/HallM/aoc2023/day14"
+	_ "github.com/HallM/aoc2023/day15"
+	_ "github.com/HallM/aoc2023/day16"
+	_ "github.com/HallM/aoc2023/day17"
+	_ "github.com/HallM/aoc2023/day18"
+	_ "github.com/HallM/aoc2023/day2"
+	_ "github.com/HallM/aoc2023/day3"
+	_ "github.com/HallM/aoc2023/day4"
+	_ "github.com/HallM/aoc2023/day5"
+	_ "github.com/HallM/aoc2023/day6"
+	_ "github.com/HallM/aoc2023/day7"
+	_ "github.com/HallM/aoc2023/day8"
+	_ "github.com/HallM/aoc2023/day9"
+)
@@ -0,0 +1,131 @@
+package days
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+// expectedAnswer is one entry of a day's expected.json, pairing a sample
+// input in that directory with the answer a part must produce for it.
+type expectedAnswer struct {
+	Part   int    `json:"part"`
+	Input  string `json:"input"`
+	Answer string `json:"answer"`
+}
+
+type goldenCase struct {
+	day      int
+	dir      string
+	expected expectedAnswer
+}
+
+func loadGoldenCases(t *testing.T) []goldenCase {
+	dirs, err := filepath.Glob(filepath.Join("..", "day*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cases []goldenCase
+	for _, dir := range dirs {
+		var day int
+		if _, err := fmt.Sscanf(filepath.Base(dir), "day%d", &day); err != nil {
+			continue
+		}
+
+		contents, err := os.ReadFile(filepath.Join(dir, "expected.json"))
+		if err != nil {
+			t.Errorf("Day %d has no expected answers: %v", day, err)
+			continue
+		}
+		var expected []expectedAnswer
+		if err := json.Unmarshal(contents, &expected); err != nil {
+			t.Errorf("Cannot parse %s/expected.json: %v", dir, err)
+			continue
+		}
+
+		covered := map[string]bool{}
+		for _, e := range expected {
+			cases = append(cases, goldenCase{day: day, dir: dir, expected: e})
+			covered[e.Input] = true
+		}
+
+		samples, err := filepath.Glob(filepath.Join(dir, "part*test.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, sample := range samples {
+			if !covered[filepath.Base(sample)] {
+				t.Errorf("Sample %s has no expected answer", sample)
+			}
+		}
+	}
+	return cases
+}
+
+func TestGoldenAnswers(t *testing.T) {
+	cases := loadGoldenCases(t)
+
+	tested := map[aoc.Key]bool{}
+	for _, c := range cases {
+		c := c
+		key := aoc.Key{Day: c.day, Part: c.expected.Part}
+		tested[key] = true
+
+		name := fmt.Sprintf("day%d/part%d/%s", c.day, c.expected.Part, strings.TrimSuffix(c.expected.Input, ".txt"))
+		t.Run(name, func(t *testing.T) {
+			solver, err := aoc.Lookup(key.Day, key.Part)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			contents, err := os.ReadFile(filepath.Join(c.dir, c.expected.Input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			answer, err := solver.Solve(string(contents))
+			if err != nil {
+				t.Fatalf("Solve returned an error: %v", err)
+			}
+			if answer != c.expected.Answer {
+				t.Errorf("wanted %s, got %s", c.expected.Answer, answer)
+			}
+
+			if s, ok := solver.(aoc.StreamSolver); ok {
+				f, err := os.Open(filepath.Join(c.dir, c.expected.Input))
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer f.Close()
+
+				answer, err := s.SolveStream(f)
+				if err != nil {
+					t.Fatalf("SolveStream returned an error: %v", err)
+				}
+				if answer != c.expected.Answer {
+					t.Errorf("wanted %s when streamed, got %s", c.expected.Answer, answer)
+				}
+			}
+		})
+	}
+
+	for _, key := range aoc.Registered() {
+		if !tested[key] {
+			t.Errorf("Day %d part %d has no golden answer", key.Day, key.Part)
+		}
+	}
+}
+
+func TestMain(m *testing.M) {
+	// The solvers log every step, which drowns out the test output.
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
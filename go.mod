@@ -0,0 +1,3 @@
+module github.com/HallM/aoc2023
+
+go 1.21
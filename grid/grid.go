@@ -0,0 +1,208 @@
+// Package grid is a dense 2D grid of cells, as used by most of the puzzles
+// that hand us a character map.
+package grid
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+type Point struct {
+	X int
+	Y int
+}
+
+func (p Point) Add(o Point) Point {
+	return Point{X: p.X + o.X, Y: p.Y + o.Y}
+}
+
+var (
+	North = Point{X: 0, Y: -1}
+	East  = Point{X: 1, Y: 0}
+	South = Point{X: 0, Y: 1}
+	West  = Point{X: -1, Y: 0}
+)
+
+// Orthogonal are the offsets to the 4 neighbors sharing an edge, clockwise from north.
+var Orthogonal = []Point{North, East, South, West}
+
+// Surrounding are the offsets to all 8 neighbors, clockwise from north.
+var Surrounding = []Point{
+	North,
+	{X: 1, Y: -1},
+	East,
+	{X: 1, Y: 1},
+	South,
+	{X: -1, Y: 1},
+	West,
+	{X: -1, Y: -1},
+}
+
+// Grid stores its cells row-major, so (x, y) lives at y*Width + x.
+type Grid[T any] struct {
+	cells  []T
+	Width  int
+	Height int
+}
+
+func New[T any](width, height int) *Grid[T] {
+	return &Grid[T]{cells: make([]T, width*height), Width: width, Height: height}
+}
+
+// Parse builds a grid from one line per row, converting each rune with mapRune.
+// Every row must be the same width, and mapRune returning false is an error.
+// Errors are an *aoc.ParseError.
+func Parse[T any](contents string, mapRune func(r rune) (T, bool)) (*Grid[T], error) {
+	return parse(contents, mapRune, "a known character")
+}
+
+// ParseMap is Parse where each rune is looked up in runes.
+func ParseMap[T any](contents string, runes map[rune]T) (*Grid[T], error) {
+	known := make([]rune, 0, len(runes))
+	for r := range runes {
+		known = append(known, r)
+	}
+	sort.Slice(known, func(i, j int) bool { return known[i] < known[j] })
+
+	return parse(contents, func(r rune) (T, bool) {
+		v, ok := runes[r]
+		return v, ok
+	}, fmt.Sprintf("one of %q", string(known)))
+}
+
+func parse[T any](contents string, mapRune func(r rune) (T, bool), expected string) (*Grid[T], error) {
+	contents = strings.ReplaceAll(contents, "\r", "")
+	// Blank lines before the grid still count towards the line numbers.
+	firstLine := 1 + len(contents) - len(strings.TrimLeft(contents, "\n"))
+	lines := aoc.SourceLines(strings.Trim(contents, "\n"), firstLine)
+	if len(lines) == 0 || len(lines[0].Text) == 0 {
+		return &Grid[T]{}, nil
+	}
+
+	width := len([]rune(lines[0].Text))
+	g := New[T](width, len(lines))
+	for y, line := range lines {
+		if n := len([]rune(line.Text)); n != width {
+			return nil, &aoc.ParseError{
+				Line:     line.Number,
+				Expected: fmt.Sprintf("a line %d wide like the first, not %d", width, n),
+				Got:      line.Text,
+				Source:   line.Text,
+			}
+		}
+		x := 0
+		for offset, r := range line.Text {
+			v, ok := mapRune(r)
+			if !ok {
+				return nil, line.Errorf(aoc.Field{Text: string(r), Offset: offset}, "%s", expected)
+			}
+			g.cells[g.Index(x, y)] = v
+			x++
+		}
+	}
+	return g, nil
+}
+
+func (g *Grid[T]) Index(x, y int) int {
+	return y*g.Width + x
+}
+
+func (g *Grid[T]) InBounds(x, y int) bool {
+	return x >= 0 && y >= 0 && x < g.Width && y < g.Height
+}
+
+func (g *Grid[T]) Get(x, y int) T {
+	return g.cells[g.Index(x, y)]
+}
+
+func (g *Grid[T]) Set(x, y int, value T) {
+	g.cells[g.Index(x, y)] = value
+}
+
+// Cells is the backing row-major slice, so changes to it change the grid.
+func (g *Grid[T]) Cells() []T {
+	return g.cells
+}
+
+// Row is a view into the grid, so changes to it change the grid.
+func (g *Grid[T]) Row(y int) []T {
+	return g.cells[y*g.Width : (y+1)*g.Width]
+}
+
+// Column is a copy, since the cells of a column are not contiguous.
+func (g *Grid[T]) Column(x int) []T {
+	col := make([]T, g.Height)
+	for y := range col {
+		col[y] = g.Get(x, y)
+	}
+	return col
+}
+
+// Neighbors calls fn for each in-bounds cell at one of the offsets from (x, y).
+func (g *Grid[T]) Neighbors(x, y int, offsets []Point, fn func(p Point, value T)) {
+	for _, o := range offsets {
+		nx, ny := x+o.X, y+o.Y
+		if g.InBounds(nx, ny) {
+			fn(Point{X: nx, Y: ny}, g.Get(nx, ny))
+		}
+	}
+}
+
+func (g *Grid[T]) Clone() *Grid[T] {
+	cells := make([]T, len(g.cells))
+	copy(cells, g.cells)
+	return &Grid[T]{cells: cells, Width: g.Width, Height: g.Height}
+}
+
+// Transpose mirrors the grid along the diagonal, so rows become columns.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	t := New[T](g.Height, g.Width)
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			t.Set(y, x, g.Get(x, y))
+		}
+	}
+	return t
+}
+
+// RotateClockwise turns the grid a quarter turn, so the west edge becomes the north edge.
+func (g *Grid[T]) RotateClockwise() *Grid[T] {
+	r := New[T](g.Height, g.Width)
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			r.Set(g.Height-1-y, x, g.Get(x, y))
+		}
+	}
+	return r
+}
+
+// RotateCounterClockwise turns the grid a quarter turn, so the east edge becomes the north edge.
+func (g *Grid[T]) RotateCounterClockwise() *Grid[T] {
+	r := New[T](g.Height, g.Width)
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			r.Set(y, g.Width-1-x, g.Get(x, y))
+		}
+	}
+	return r
+}
+
+// Lines renders each row as a string using toRune for every cell.
+func (g *Grid[T]) Lines(toRune func(value T) rune) []string {
+	lines := make([]string, 0, g.Height)
+	for y := 0; y < g.Height; y++ {
+		row := make([]rune, 0, g.Width)
+		for _, c := range g.Row(y) {
+			row = append(row, toRune(c))
+		}
+		lines = append(lines, string(row))
+	}
+	return lines
+}
+
+func (g *Grid[T]) Render(toRune func(value T) rune) string {
+	return strings.Join(g.Lines(toRune), "\n")
+}
@@ -0,0 +1,92 @@
+package grid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/HallM/aoc2023/aoc"
+)
+
+func identity(r rune) (rune, bool) {
+	return r, true
+}
+
+func parseOrFail(t *testing.T, contents string) *Grid[rune] {
+	t.Helper()
+	g, err := Parse(contents, identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func render(g *Grid[rune]) string {
+	return g.Render(func(r rune) rune { return r })
+}
+
+func TestParse(t *testing.T) {
+	g := parseOrFail(t, "abc\r\ndef\n")
+	if g.Width != 3 || g.Height != 2 {
+		t.Fatalf("wanted 3x2, got %dx%d", g.Width, g.Height)
+	}
+	if string(g.Row(1)) != "def" {
+		t.Errorf("wanted row 1 %q, got %q", "def", string(g.Row(1)))
+	}
+	if string(g.Column(2)) != "cf" {
+		t.Errorf("wanted column 2 %q, got %q", "cf", string(g.Column(2)))
+	}
+
+	if _, err := Parse("abc\nde", identity); err == nil {
+		t.Errorf("wanted an error for mismatched line lengths")
+	}
+	if _, err := ParseMap("ab", map[rune]int{'a': 1}); err == nil {
+		t.Errorf("wanted an error for an unmapped rune")
+	}
+}
+
+func TestParseErrorPosition(t *testing.T) {
+	_, err := ParseMap("\n..\n.x", map[rune]int{'.': 0, '#': 1})
+
+	var perr *aoc.ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("wanted a ParseError, got %v", err)
+	}
+	if perr.Line != 3 || perr.Column != 2 || perr.Got != "x" || perr.Expected != `one of "#."` {
+		t.Errorf("wanted x at 3:2 expecting one of \"#.\", got %+v", perr)
+	}
+}
+
+func TestTransforms(t *testing.T) {
+	g := parseOrFail(t, "abc\ndef")
+
+	tests := []struct {
+		name string
+		got  *Grid[rune]
+		want string
+	}{
+		{"transpose", g.Transpose(), "ad\nbe\ncf"},
+		{"clockwise", g.RotateClockwise(), "da\neb\nfc"},
+		{"counter clockwise", g.RotateCounterClockwise(), "cf\nbe\nad"},
+		{"full turn", g.RotateClockwise().RotateClockwise().RotateClockwise().RotateClockwise(), "abc\ndef"},
+	}
+	for _, tt := range tests {
+		if got := render(tt.got); got != tt.want {
+			t.Errorf("%s: wanted %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := parseOrFail(t, "abc\ndef\nghi")
+
+	var orthogonal, surrounding []rune
+	g.Neighbors(0, 0, Orthogonal, func(p Point, v rune) { orthogonal = append(orthogonal, v) })
+	g.Neighbors(1, 1, Surrounding, func(p Point, v rune) { surrounding = append(surrounding, v) })
+
+	if string(orthogonal) != "bd" {
+		t.Errorf("wanted orthogonal neighbors %q, got %q", "bd", string(orthogonal))
+	}
+	if string(surrounding) != "bcfihgda" {
+		t.Errorf("wanted surrounding neighbors %q, got %q", "bcfihgda", string(surrounding))
+	}
+}
@@ -0,0 +1,137 @@
+// Package interval works with half-open ranges of integers, both as sets
+// and as maps that shift each range somewhere else.
+package interval
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Interval is every integer from Start up to but not including End.
+type Interval[T Integer] struct {
+	Start T
+	End   T
+}
+
+func (iv Interval[T]) Empty() bool {
+	return iv.End <= iv.Start
+}
+
+func (iv Interval[T]) Len() T {
+	if iv.Empty() {
+		return 0
+	}
+	return iv.End - iv.Start
+}
+
+func (iv Interval[T]) Contains(x T) bool {
+	return iv.Start <= x && x < iv.End
+}
+
+// Intersect is the part both intervals cover, which may be empty.
+func (iv Interval[T]) Intersect(other Interval[T]) Interval[T] {
+	return Interval[T]{Start: max(iv.Start, other.Start), End: min(iv.End, other.End)}
+}
+
+// Shift moves the whole interval along by offset.
+func (iv Interval[T]) Shift(offset T) Interval[T] {
+	return Interval[T]{Start: iv.Start + offset, End: iv.End + offset}
+}
+
+func (iv Interval[T]) String() string {
+	return fmt.Sprintf("[%d, %d)", iv.Start, iv.End)
+}
+
+// Set is a sorted list of intervals that neither overlap nor touch.
+type Set[T Integer] struct {
+	intervals []Interval[T]
+}
+
+// NewSet merges the intervals together, dropping any that are empty.
+func NewSet[T Integer](intervals ...Interval[T]) Set[T] {
+	sorted := make([]Interval[T], 0, len(intervals))
+	for _, iv := range intervals {
+		if !iv.Empty() {
+			sorted = append(sorted, iv)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	var merged []Interval[T]
+	for _, iv := range sorted {
+		if n := len(merged); n > 0 && iv.Start <= merged[n-1].End {
+			merged[n-1].End = max(merged[n-1].End, iv.End)
+			continue
+		}
+		merged = append(merged, iv)
+	}
+	return Set[T]{intervals: merged}
+}
+
+// Intervals are the set's intervals in order. The slice must not be changed.
+func (s Set[T]) Intervals() []Interval[T] {
+	return s.intervals
+}
+
+func (s Set[T]) Empty() bool {
+	return len(s.intervals) == 0
+}
+
+// Len is how many integers are in the set.
+func (s Set[T]) Len() T {
+	var total T
+	for _, iv := range s.intervals {
+		total += iv.Len()
+	}
+	return total
+}
+
+// Min is the smallest integer in the set, if there is one.
+func (s Set[T]) Min() (T, bool) {
+	if s.Empty() {
+		var zero T
+		return zero, false
+	}
+	return s.intervals[0].Start, true
+}
+
+func (s Set[T]) Contains(x T) bool {
+	i := sort.Search(len(s.intervals), func(i int) bool {
+		return s.intervals[i].End > x
+	})
+	return i < len(s.intervals) && s.intervals[i].Contains(x)
+}
+
+func (s Set[T]) Equal(other Set[T]) bool {
+	return slices.Equal(s.intervals, other.intervals)
+}
+
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	all := append(append([]Interval[T]{}, s.intervals...), other.intervals...)
+	return NewSet(all...)
+}
+
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	var both []Interval[T]
+	a, b := s.intervals, other.intervals
+	for len(a) > 0 && len(b) > 0 {
+		if iv := a[0].Intersect(b[0]); !iv.Empty() {
+			both = append(both, iv)
+		}
+		// whichever ends first can't overlap anything else in the other set
+		if a[0].End < b[0].End {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return Set[T]{intervals: both}
+}
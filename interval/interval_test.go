@@ -0,0 +1,145 @@
+package interval
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func iv(start, end int64) Interval[int64] {
+	return Interval[int64]{Start: start, End: end}
+}
+
+func TestSet(t *testing.T) {
+	s := NewSet(iv(10, 20), iv(0, 5), iv(5, 8), iv(15, 25), iv(30, 30))
+	if want := []Interval[int64]{iv(0, 8), iv(10, 25)}; !reflect.DeepEqual(s.Intervals(), want) {
+		t.Errorf("wanted %v, got %v", want, s.Intervals())
+	}
+	if s.Len() != 23 {
+		t.Errorf("wanted 23 integers, got %d", s.Len())
+	}
+	for x, want := range map[int64]bool{0: true, 7: true, 8: false, 9: false, 24: true, 25: false} {
+		if s.Contains(x) != want {
+			t.Errorf("wanted Contains(%d) to be %v", x, want)
+		}
+	}
+
+	other := NewSet(iv(6, 12), iv(20, 40))
+	if want := []Interval[int64]{iv(6, 8), iv(10, 12), iv(20, 25)}; !reflect.DeepEqual(s.Intersect(other).Intervals(), want) {
+		t.Errorf("wanted intersection %v, got %v", want, s.Intersect(other).Intervals())
+	}
+	if want := []Interval[int64]{iv(0, 40)}; !reflect.DeepEqual(s.Union(other).Intervals(), want) {
+		t.Errorf("wanted union %v, got %v", want, s.Union(other).Intervals())
+	}
+}
+
+// the seed-to-soil map from the day 5 example
+func seedToSoil(t *testing.T) *IntervalMap[int64] {
+	t.Helper()
+	m, err := NewIntervalMap(
+		Mapping[int64]{Interval: iv(98, 100), Offset: -48},
+		Mapping[int64]{Interval: iv(50, 98), Offset: 2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestLookup(t *testing.T) {
+	m := seedToSoil(t)
+	for x, want := range map[int64]int64{0: 0, 49: 49, 50: 52, 79: 81, 97: 99, 98: 50, 99: 51, 100: 100} {
+		if got := m.Lookup(x); got != want {
+			t.Errorf("wanted %d to map to %d, got %d", x, want, got)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	got := seedToSoil(t).Split(iv(40, 105))
+	want := []Mapping[int64]{
+		{Interval: iv(40, 50)},
+		{Interval: iv(50, 98), Offset: 2},
+		{Interval: iv(98, 100), Offset: -48},
+		{Interval: iv(100, 105)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wanted %v, got %v", want, got)
+	}
+
+	if got := seedToSoil(t).Image(NewSet(iv(79, 93), iv(55, 68))); !reflect.DeepEqual(got.Intervals(), []Interval[int64]{iv(57, 70), iv(81, 95)}) {
+		t.Errorf("wanted the example seeds as soil, got %v", got.Intervals())
+	}
+}
+
+func TestOverlap(t *testing.T) {
+	_, err := NewIntervalMap(
+		Mapping[int64]{Interval: iv(0, 10), Offset: 1},
+		Mapping[int64]{Interval: iv(20, 30), Offset: 1},
+		Mapping[int64]{Interval: iv(5, 15), Offset: 1},
+	)
+	overlap, ok := err.(*OverlapError[int64])
+	if !ok {
+		t.Fatalf("wanted an OverlapError, got %v", err)
+	}
+	if overlap.First != 0 || overlap.Second != 2 {
+		t.Errorf("wanted mappings 0 and 2 to overlap, got %d and %d", overlap.First, overlap.Second)
+	}
+}
+
+func TestCompose(t *testing.T) {
+	first := seedToSoil(t)
+	second, err := NewIntervalMap(
+		Mapping[int64]{Interval: iv(15, 52), Offset: -15},
+		Mapping[int64]{Interval: iv(52, 54), Offset: -15},
+		Mapping[int64]{Interval: iv(0, 15), Offset: 39},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	composed := first.Compose(second)
+	for x := int64(-5); x < 110; x++ {
+		if want, got := second.Lookup(first.Lookup(x)), composed.Lookup(x); got != want {
+			t.Errorf("wanted %d to map to %d, got %d", x, want, got)
+		}
+	}
+	for i, m := range composed.Mappings() {
+		if m.Offset == 0 {
+			t.Errorf("wanted no identity mappings, got %v", m)
+		}
+		if i > 0 && composed.Mappings()[i-1].End > m.Start {
+			t.Errorf("wanted sorted mappings, got %v", composed.Mappings())
+		}
+	}
+}
+
+func TestInverse(t *testing.T) {
+	m := seedToSoil(t)
+	inv, err := m.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for x := int64(40); x < 110; x++ {
+		if got := inv.Lookup(m.Lookup(x)); got != x {
+			t.Errorf("wanted %d back, got %d", x, got)
+		}
+		if got := m.Preimage(m.Lookup(x)); !reflect.DeepEqual(got, []int64{x}) {
+			t.Errorf("wanted only %d to map to %d, got %v", x, m.Lookup(x), got)
+		}
+	}
+
+	squashed, err := NewIntervalMap(Mapping[int64]{Interval: iv(0, 5), Offset: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := squashed.Inverse(); !errors.Is(err, ErrNotOneToOne) {
+		t.Errorf("wanted ErrNotOneToOne, got %v", err)
+	}
+	if got := squashed.Preimage(12); !reflect.DeepEqual(got, []int64{2, 12}) {
+		t.Errorf("wanted 2 and 12 to map to 12, got %v", got)
+	}
+	if got := squashed.Preimage(3); len(got) != 0 {
+		t.Errorf("wanted nothing to map to 3, got %v", got)
+	}
+}
@@ -0,0 +1,188 @@
+package interval
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Mapping sends every x in its interval to x + Offset.
+type Mapping[T Integer] struct {
+	Interval[T]
+	Offset T
+}
+
+// IntervalMap is a sorted list of mappings that don't overlap. Anything
+// outside all of them maps to itself.
+type IntervalMap[T Integer] struct {
+	mappings []Mapping[T]
+}
+
+// OverlapError says which two of the mappings given to NewIntervalMap overlap.
+type OverlapError[T Integer] struct {
+	// First and Second are indexes into the mappings as they were given.
+	First  int
+	Second int
+	A      Interval[T]
+	B      Interval[T]
+}
+
+func (e *OverlapError[T]) Error() string {
+	return fmt.Sprintf("Intervals %v and %v overlap", e.A, e.B)
+}
+
+// NewIntervalMap sorts the mappings, dropping any that are empty.
+func NewIntervalMap[T Integer](mappings ...Mapping[T]) (*IntervalMap[T], error) {
+	order := make([]int, 0, len(mappings))
+	for i, m := range mappings {
+		if !m.Empty() {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return mappings[order[i]].Start < mappings[order[j]].Start
+	})
+
+	sorted := make([]Mapping[T], len(order))
+	for i, o := range order {
+		sorted[i] = mappings[o]
+		if i > 0 && sorted[i].Start < sorted[i-1].End {
+			return nil, &OverlapError[T]{First: order[i-1], Second: o, A: sorted[i-1].Interval, B: sorted[i].Interval}
+		}
+	}
+	return &IntervalMap[T]{mappings: sorted}, nil
+}
+
+// Mappings are the map's mappings in order. The slice must not be changed.
+func (m *IntervalMap[T]) Mappings() []Mapping[T] {
+	return m.mappings
+}
+
+// find is the index of the first mapping that ends after x.
+func (m *IntervalMap[T]) find(x T) int {
+	return sort.Search(len(m.mappings), func(i int) bool {
+		return m.mappings[i].End > x
+	})
+}
+
+// Lookup is where x maps to.
+func (m *IntervalMap[T]) Lookup(x T) T {
+	if i := m.find(x); i < len(m.mappings) && m.mappings[i].Contains(x) {
+		return x + m.mappings[i].Offset
+	}
+	return x
+}
+
+// Split cuts iv wherever a mapping starts or ends, so each piece moves by a
+// single offset. Pieces outside every mapping have an offset of 0.
+func (m *IntervalMap[T]) Split(iv Interval[T]) []Mapping[T] {
+	var pieces []Mapping[T]
+	at := iv.Start
+	for i := m.find(at); at < iv.End; i++ {
+		if i == len(m.mappings) {
+			pieces = append(pieces, Mapping[T]{Interval: Interval[T]{Start: at, End: iv.End}})
+			break
+		}
+		next := m.mappings[i]
+		if at < next.Start {
+			gap := Interval[T]{Start: at, End: min(next.Start, iv.End)}
+			pieces = append(pieces, Mapping[T]{Interval: gap})
+			at = gap.End
+		}
+		if at < iv.End {
+			covered := Interval[T]{Start: at, End: min(next.End, iv.End)}
+			pieces = append(pieces, Mapping[T]{Interval: covered, Offset: next.Offset})
+			at = covered.End
+		}
+	}
+	return pieces
+}
+
+// Image is everywhere the integers in s map to.
+func (m *IntervalMap[T]) Image(s Set[T]) Set[T] {
+	var moved []Interval[T]
+	for _, iv := range s.Intervals() {
+		for _, piece := range m.Split(iv) {
+			moved = append(moved, piece.Shift(piece.Offset))
+		}
+	}
+	return NewSet(moved...)
+}
+
+// Compose is the single map that does m and then next.
+func (m *IntervalMap[T]) Compose(next *IntervalMap[T]) *IntervalMap[T] {
+	if len(m.mappings) == 0 {
+		return next
+	}
+	if len(next.mappings) == 0 {
+		return m
+	}
+
+	// Outside of span, both maps leave everything where it is.
+	span := Interval[T]{
+		Start: min(m.mappings[0].Start, next.mappings[0].Start),
+		End:   max(m.mappings[len(m.mappings)-1].End, next.mappings[len(next.mappings)-1].End),
+	}
+
+	var composed []Mapping[T]
+	for _, first := range m.Split(span) {
+		for _, second := range next.Split(first.Shift(first.Offset)) {
+			offset := first.Offset + second.Offset
+			if offset == 0 {
+				continue
+			}
+			piece := Mapping[T]{Interval: second.Shift(-first.Offset), Offset: offset}
+			if n := len(composed); n > 0 && composed[n-1].End == piece.Start && composed[n-1].Offset == offset {
+				composed[n-1].End = piece.End
+				continue
+			}
+			composed = append(composed, piece)
+		}
+	}
+	return &IntervalMap[T]{mappings: composed}
+}
+
+// Preimage is every x that maps to y, in order.
+func (m *IntervalMap[T]) Preimage(y T) []T {
+	var found []T
+	for _, mapping := range m.mappings {
+		if x := y - mapping.Offset; mapping.Contains(x) {
+			found = append(found, x)
+		}
+	}
+	if i := m.find(y); i == len(m.mappings) || !m.mappings[i].Contains(y) {
+		found = append(found, y)
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i] < found[j]
+	})
+	return found
+}
+
+// ErrNotOneToOne is returned by Inverse when some integers are mapped to by more than one x.
+var ErrNotOneToOne = errors.New("Map is not one-to-one")
+
+// Inverse undoes the map. That's only possible when the mappings rearrange
+// the integers they cover among themselves, or else something they move
+// lands on top of an integer that stays where it is.
+func (m *IntervalMap[T]) Inverse() (*IntervalMap[T], error) {
+	domain := make([]Interval[T], len(m.mappings))
+	inverse := make([]Mapping[T], len(m.mappings))
+	for i, mapping := range m.mappings {
+		domain[i] = mapping.Interval
+		inverse[i] = Mapping[T]{Interval: mapping.Shift(mapping.Offset), Offset: -mapping.Offset}
+	}
+
+	inv, err := NewIntervalMap(inverse...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotOneToOne, err)
+	}
+	var image []Interval[T]
+	for _, mapping := range inv.mappings {
+		image = append(image, mapping.Interval)
+	}
+	if !NewSet(image...).Equal(NewSet(domain...)) {
+		return nil, fmt.Errorf("%w: integers moved onto ones that stay put", ErrNotOneToOne)
+	}
+	return inv, nil
+}
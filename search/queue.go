@@ -0,0 +1,53 @@
+package search
+
+import (
+	"container/heap"
+)
+
+// PriorityQueue pops the item that sorts first according to less.
+type PriorityQueue[T any] struct {
+	items queueItems[T]
+}
+
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{items: queueItems[T]{less: less}}
+}
+
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.items.values)
+}
+
+func (q *PriorityQueue[T]) Push(value T) {
+	heap.Push(&q.items, value)
+}
+
+func (q *PriorityQueue[T]) Pop() T {
+	return heap.Pop(&q.items).(T)
+}
+
+// queueItems is the heap.Interface the queue hides, so callers never deal with any.
+type queueItems[T any] struct {
+	values []T
+	less   func(a, b T) bool
+}
+
+func (qi queueItems[T]) Len() int {
+	return len(qi.values)
+}
+func (qi queueItems[T]) Less(i, j int) bool {
+	return qi.less(qi.values[i], qi.values[j])
+}
+func (qi queueItems[T]) Swap(i, j int) {
+	qi.values[i], qi.values[j] = qi.values[j], qi.values[i]
+}
+func (qi *queueItems[T]) Push(v any) {
+	qi.values = append(qi.values, v.(T))
+}
+func (qi *queueItems[T]) Pop() any {
+	size := len(qi.values)
+	v := qi.values[size-1]
+	var zero T
+	qi.values[size-1] = zero
+	qi.values = qi.values[0 : size-1]
+	return v
+}
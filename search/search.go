@@ -0,0 +1,104 @@
+// Package search finds cheapest paths through any graph described by a
+// neighbor function, so the state being searched is up to the caller.
+package search
+
+// Edge is a state reachable from another state and the cost of moving to it.
+type Edge[S comparable] struct {
+	To   S
+	Cost int
+}
+
+// NeighborFunc lists every state reachable in one move from the given state.
+type NeighborFunc[S comparable] func(state S) []Edge[S]
+
+// Result is the cheapest path found, from a start state to the goal inclusive.
+type Result[S comparable] struct {
+	Path []S
+	Cost int
+}
+
+type queued[S comparable] struct {
+	state    S
+	cost     int
+	estimate int
+	// order breaks ties so the search is deterministic
+	order int
+}
+
+// Dijkstra finds the cheapest path from any of the starts to a state where isGoal is true.
+func Dijkstra[S comparable](starts []S, neighbors NeighborFunc[S], isGoal func(S) bool) (Result[S], bool) {
+	return AStar(starts, neighbors, func(S) int { return 0 }, isGoal)
+}
+
+// AStar is Dijkstra guided by heuristic, which must never overestimate the
+// remaining cost to a goal or the path found may not be the cheapest.
+func AStar[S comparable](starts []S, neighbors NeighborFunc[S], heuristic func(S) int, isGoal func(S) bool) (Result[S], bool) {
+	costs, previous, goal, found := explore(starts, neighbors, heuristic, isGoal)
+	if !found {
+		return Result[S]{}, false
+	}
+
+	path := []S{goal}
+	for {
+		prev, ok := previous[path[len(path)-1]]
+		if !ok {
+			break
+		}
+		path = append(path, prev)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return Result[S]{Path: path, Cost: costs[goal]}, true
+}
+
+// Costs explores everything reachable from the starts and returns the cheapest cost to each state.
+func Costs[S comparable](starts []S, neighbors NeighborFunc[S]) map[S]int {
+	costs, _, _, _ := explore(starts, neighbors, func(S) int { return 0 }, func(S) bool { return false })
+	return costs
+}
+
+func explore[S comparable](starts []S, neighbors NeighborFunc[S], heuristic func(S) int, isGoal func(S) bool) (map[S]int, map[S]S, S, bool) {
+	queue := NewPriorityQueue(func(a, b queued[S]) bool {
+		if a.estimate != b.estimate {
+			return a.estimate < b.estimate
+		}
+		return a.order < b.order
+	})
+
+	costs := map[S]int{}
+	previous := map[S]S{}
+	visited := map[S]bool{}
+
+	order := 0
+	for _, s := range starts {
+		costs[s] = 0
+		queue.Push(queued[S]{state: s, cost: 0, estimate: heuristic(s), order: order})
+		order++
+	}
+
+	for queue.Len() > 0 {
+		q := queue.Pop()
+		if visited[q.state] {
+			continue
+		}
+		if isGoal(q.state) {
+			return costs, previous, q.state, true
+		}
+		visited[q.state] = true
+
+		for _, e := range neighbors(q.state) {
+			cost := q.cost + e.Cost
+			if prev, ok := costs[e.To]; ok && prev <= cost {
+				continue
+			}
+			costs[e.To] = cost
+			previous[e.To] = q.state
+			queue.Push(queued[S]{state: e.To, cost: cost, estimate: cost + heuristic(e.To), order: order})
+			order++
+		}
+	}
+
+	var none S
+	return costs, previous, none, false
+}
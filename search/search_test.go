@@ -0,0 +1,95 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	q := NewPriorityQueue(func(a, b int) bool { return a < b })
+	for _, v := range []int{5, 1, 4, 2, 3} {
+		q.Push(v)
+	}
+
+	var got []int
+	for q.Len() > 0 {
+		got = append(got, q.Pop())
+	}
+	if want := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("wanted %v, got %v", want, got)
+	}
+}
+
+// a small weighted graph where the direct a->d edge is more expensive than going around
+var weighted = map[string][]Edge[string]{
+	"a": {{To: "b", Cost: 1}, {To: "d", Cost: 10}},
+	"b": {{To: "c", Cost: 2}},
+	"c": {{To: "d", Cost: 3}},
+	"e": {{To: "a", Cost: 1}},
+}
+
+func weightedNeighbors(s string) []Edge[string] {
+	return weighted[s]
+}
+
+func TestDijkstra(t *testing.T) {
+	result, ok := Dijkstra([]string{"a"}, weightedNeighbors, func(s string) bool { return s == "d" })
+	if !ok {
+		t.Fatalf("wanted a path to d")
+	}
+	if want := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(result.Path, want) {
+		t.Errorf("wanted path %v, got %v", want, result.Path)
+	}
+	if result.Cost != 6 {
+		t.Errorf("wanted cost 6, got %d", result.Cost)
+	}
+
+	if _, ok := Dijkstra([]string{"a"}, weightedNeighbors, func(s string) bool { return s == "e" }); ok {
+		t.Errorf("e is not reachable from a")
+	}
+}
+
+func TestAStarGrid(t *testing.T) {
+	type point struct{ x, y int }
+	const size = 10
+	// a wall down the middle with a gap only at the bottom
+	neighbors := func(p point) []Edge[point] {
+		var edges []Edge[point]
+		for _, o := range []point{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			n := point{p.x + o.x, p.y + o.y}
+			if n.x < 0 || n.y < 0 || n.x >= size || n.y >= size || (n.x == 5 && n.y < size-1) {
+				continue
+			}
+			edges = append(edges, Edge[point]{To: n, Cost: 1})
+		}
+		return edges
+	}
+	goal := point{size - 1, 0}
+	heuristic := func(p point) int {
+		dx, dy := goal.x-p.x, goal.y-p.y
+		if dx < 0 {
+			dx = -dx
+		}
+		if dy < 0 {
+			dy = -dy
+		}
+		return dx + dy
+	}
+
+	result, ok := AStar([]point{{0, 0}}, neighbors, heuristic, func(p point) bool { return p == goal })
+	if !ok {
+		t.Fatalf("wanted a path around the wall")
+	}
+	want := 9 + 2*(size-1)
+	if result.Cost != want || len(result.Path) != want+1 {
+		t.Errorf("wanted cost %d with %d steps, got cost %d with %d steps", want, want+1, result.Cost, len(result.Path))
+	}
+}
+
+func TestCosts(t *testing.T) {
+	costs := Costs([]string{"a"}, weightedNeighbors)
+	want := map[string]int{"a": 0, "b": 1, "c": 3, "d": 6}
+	if !reflect.DeepEqual(costs, want) {
+		t.Errorf("wanted %v, got %v", want, costs)
+	}
+}